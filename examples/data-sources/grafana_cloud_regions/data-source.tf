@@ -0,0 +1,7 @@
+data "grafana_cloud_regions" "available" {}
+
+resource "grafana_cloud_stack" "test" {
+  name        = "gcloudstacktest"
+  slug        = "gcloudstacktest"
+  region_slug = data.grafana_cloud_regions.available.slugs[0]
+}
@@ -0,0 +1,24 @@
+data "grafana_jsonnet" "dashboard" {
+  source = <<EOT
+local title = std.extVar('title');
+{
+  title: title,
+  uid: std.asciiLower(title),
+  panels: [
+    { title: 'Panel %d' % i, type: 'text', gridPos: { x: 0, y: i * 4, w: 24, h: 4 } }
+    for i in std.range(1, std.extVar('panels'))
+  ],
+}
+EOT
+
+  ext_vars = {
+    title = "Jsonnet"
+  }
+  ext_code = {
+    panels = "2"
+  }
+}
+
+resource "grafana_dashboard" "jsonnet" {
+  config_json = data.grafana_jsonnet.dashboard.config_json
+}
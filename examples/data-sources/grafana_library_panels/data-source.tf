@@ -0,0 +1,38 @@
+resource "grafana_folder" "test" {
+  title = "library panels"
+}
+
+resource "grafana_library_panel" "text" {
+  name      = "library panels text"
+  folder_id = grafana_folder.test.id
+  model_json = jsonencode({
+    title = "library panels text"
+    type  = "text"
+  })
+}
+
+resource "grafana_library_panel" "stat" {
+  name      = "library panels stat"
+  folder_id = grafana_folder.test.id
+  model_json = jsonencode({
+    title = "library panels stat"
+    type  = "stat"
+  })
+}
+
+// all the library panels of the folder
+data "grafana_library_panels" "folder" {
+  folder_ids = [grafana_folder.test.id]
+
+  // the library panels must be created before searching for them
+  depends_on = [grafana_library_panel.text, grafana_library_panel.stat]
+}
+
+// the text library panels of the folder whose name or description contains "library panels"
+data "grafana_library_panels" "text" {
+  folder_ids = [grafana_folder.test.id]
+  types      = ["text"]
+  search     = "library panels"
+
+  depends_on = [grafana_library_panel.text, grafana_library_panel.stat]
+}
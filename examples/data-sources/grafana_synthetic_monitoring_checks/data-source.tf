@@ -0,0 +1,26 @@
+data "grafana_synthetic_monitoring_probes" "main" {}
+
+resource "grafana_synthetic_monitoring_check" "http" {
+  job     = "Checks data source"
+  target  = "https://grafana.com"
+  enabled = false
+  probes = [
+    data.grafana_synthetic_monitoring_probes.main.probes.Atlanta,
+  ]
+  labels = {
+    team = "checks-data-source"
+  }
+  settings {
+    http {}
+  }
+}
+
+data "grafana_synthetic_monitoring_checks" "team" {
+  type    = "http"
+  enabled = false
+  labels = {
+    team = "checks-data-source"
+  }
+
+  depends_on = [grafana_synthetic_monitoring_check.http]
+}
@@ -0,0 +1,5 @@
+data "grafana_synthetic_monitoring_tenant" "main" {}
+
+output "remaining_checks" {
+  value = data.grafana_synthetic_monitoring_tenant.main.max_checks - data.grafana_synthetic_monitoring_tenant.main.checks_count
+}
@@ -0,0 +1,27 @@
+// Step 1: Create a stack
+provider "grafana" {
+  alias         = "cloud"
+  cloud_api_key = "my-token"
+}
+
+resource "grafana_cloud_stack" "my_stack" {
+  provider = grafana.cloud
+
+  name        = "myteststack"
+  slug        = "myteststack"
+  region_slug = "us"
+}
+
+// Step 2: Create resources within the stack, in the same apply
+provider "grafana" {
+  alias = "my_stack"
+
+  cloud_api_key    = "my-token"
+  cloud_stack_slug = grafana_cloud_stack.my_stack.slug
+}
+
+resource "grafana_folder" "my_folder" {
+  provider = grafana.my_stack
+
+  title = "Test Folder"
+}
@@ -0,0 +1,49 @@
+resource "grafana_data_source" "inputs" {
+  type = "prometheus"
+  name = "inputs"
+  url  = "http://localhost:9090"
+}
+
+// A dashboard exported for sharing externally. Its datasource input is set in `inputs`,
+// its constant input keeps the value it was exported with.
+resource "grafana_dashboard" "test" {
+  config_json = jsonencode({
+    __inputs = [
+      {
+        name     = "DS_PROMETHEUS"
+        label    = "Prometheus"
+        type     = "datasource"
+        pluginId = "prometheus"
+      },
+      {
+        name  = "VAR_JOB"
+        label = "job"
+        type  = "constant"
+        value = "node"
+      },
+    ]
+    __requires = [
+      {
+        type = "datasource"
+        id   = "prometheus"
+        name = "Prometheus"
+      },
+    ]
+    title = "Terraform Inputs Test"
+    uid   = "inputs"
+    panels = [
+      {
+        title      = "Up"
+        type       = "timeseries"
+        datasource = { type = "prometheus", uid = "$${DS_PROMETHEUS}" }
+        targets = [
+          { expr = "up{job=\"$${VAR_JOB}\"}", refId = "A" },
+        ]
+      },
+    ]
+  })
+
+  inputs = {
+    DS_PROMETHEUS = grafana_data_source.inputs.uid
+  }
+}
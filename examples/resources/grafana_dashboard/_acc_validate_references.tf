@@ -0,0 +1,23 @@
+resource "grafana_data_source" "references" {
+  type = "prometheus"
+  name = "references"
+  url  = "http://localhost:9090"
+}
+
+resource "grafana_dashboard" "test" {
+  validate_references = true
+  config_json = jsonencode({
+    title = "Terraform Validate References Test"
+    uid   = "validate-references"
+    panels = [
+      {
+        title      = "Up"
+        type       = "timeseries"
+        datasource = { type = "prometheus", uid = grafana_data_source.references.uid }
+        targets = [
+          { expr = "up", refId = "A" },
+        ]
+      },
+    ]
+  })
+}
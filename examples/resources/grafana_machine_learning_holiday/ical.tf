@@ -0,0 +1,6 @@
+resource "grafana_machine_learning_holiday" "test_holiday" {
+  name          = "Test Holiday"
+  description   = "Public holidays in the UK"
+  ical_url      = "https://calendar.google.com/calendar/ical/en.uk%23holiday%40group.v.calendar.google.com/public/basic.ics"
+  ical_timezone = "Europe/London"
+}
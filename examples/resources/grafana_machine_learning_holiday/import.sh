@@ -0,0 +1 @@
+terraform import grafana_machine_learning_holiday.test_holiday {{holiday_id}}
@@ -0,0 +1,15 @@
+resource "grafana_machine_learning_holiday" "test_holiday" {
+  name        = "Test Holiday"
+  description = "Company offsite"
+
+  custom_periods {
+    name       = "First day"
+    start_time = "2023-01-02T00:00:00Z"
+    end_time   = "2023-01-03T00:00:00Z"
+  }
+  custom_periods {
+    name       = "Second day"
+    start_time = "2023-01-03T00:00:00Z"
+    end_time   = "2023-01-04T00:00:00Z"
+  }
+}
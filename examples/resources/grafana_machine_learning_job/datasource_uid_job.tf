@@ -0,0 +1,11 @@
+resource "grafana_machine_learning_job" "test_job" {
+  name               = "Test Job"
+  metric             = "tf_test_job"
+  datasource_type    = "prometheus"
+  datasource_uid     = "grafanacloud-prom"
+  algorithm          = "Prophet"
+  training_frequency = 43200
+  query_params = {
+    expr = "grafanacloud_grafana_instance_active_user_count"
+  }
+}
@@ -0,0 +1,18 @@
+resource "grafana_machine_learning_outlier_detector" "test_outlier_detector" {
+  name   = "Test Outlier Detector"
+  metric = "tf_test_dbscan_job"
+
+  datasource_type = "prometheus"
+  datasource_uid  = "grafanacloud-prom"
+  query_params = {
+    expr = "grafanacloud_grafana_instance_active_user_count"
+  }
+
+  algorithm {
+    name        = "dbscan"
+    sensitivity = 0.5
+    config {
+      epsilon = 1.0
+    }
+  }
+}
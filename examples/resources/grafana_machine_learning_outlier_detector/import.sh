@@ -0,0 +1 @@
+terraform import grafana_machine_learning_outlier_detector.test_outlier_detector {{outlier_detector_id}}
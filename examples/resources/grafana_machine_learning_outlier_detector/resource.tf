@@ -0,0 +1,17 @@
+resource "grafana_machine_learning_outlier_detector" "test_outlier_detector" {
+  name        = "Test Outlier Detector"
+  metric      = "tf_test_mad_job"
+  description = "Finds outliers in active user counts"
+
+  datasource_type = "prometheus"
+  datasource_uid  = "grafanacloud-prom"
+  query_params = {
+    expr = "grafanacloud_grafana_instance_active_user_count"
+  }
+  interval = 300
+
+  algorithm {
+    name        = "mad"
+    sensitivity = 0.7
+  }
+}
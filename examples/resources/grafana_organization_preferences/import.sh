@@ -0,0 +1 @@
+terraform import grafana_organization_preferences.preferences {{org_id}}
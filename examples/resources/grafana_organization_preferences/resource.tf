@@ -0,0 +1,15 @@
+resource "grafana_dashboard" "metrics" {
+  config_json = jsonencode({
+    title = "Organization Home Dashboard"
+    uid   = "org-home"
+  })
+}
+
+resource "grafana_organization_preferences" "preferences" {
+  theme                  = "light"
+  timezone               = "utc"
+  week_start             = "sunday"
+  locale                 = "en-US"
+  query_history_home_tab = "starred"
+  home_dashboard_uid     = grafana_dashboard.metrics.uid
+}
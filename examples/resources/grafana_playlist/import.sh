@@ -0,0 +1 @@
+terraform import grafana_playlist.test {{playlist_uid}}
@@ -0,0 +1,25 @@
+resource "grafana_dashboard" "test" {
+  config_json = jsonencode({
+    title = "Playlist Dashboard"
+    uid   = "playlist-dashboard"
+  })
+}
+
+resource "grafana_playlist" "test" {
+  name     = "My Playlist"
+  interval = "5m"
+
+  item {
+    order = 1
+    title = "Terraform Dashboards"
+    type  = "dashboard_by_tag"
+    value = "terraform"
+  }
+
+  item {
+    order = 2
+    title = "Playlist Dashboard"
+    type  = "dashboard_by_uid"
+    value = grafana_dashboard.test.uid
+  }
+}
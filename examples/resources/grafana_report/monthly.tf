@@ -0,0 +1,21 @@
+resource "grafana_dashboard" "test" {
+  config_json = <<EOD
+{
+  "title": "Dashboard for report",
+  "uid": "report"
+}
+EOD
+  message     = "inital commit."
+}
+
+resource "grafana_report" "test" {
+  name          = "my report updated"
+  dashboard_uid = grafana_dashboard.test.uid
+  recipients    = ["some@email.com"]
+  schedule {
+    frequency         = "monthly"
+    timezone          = "Europe/Berlin"
+    start_time        = "2020-01-31T09:00:00+01:00"
+    last_day_of_month = true
+  }
+}
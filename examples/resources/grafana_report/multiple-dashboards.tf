@@ -0,0 +1,44 @@
+resource "grafana_dashboard" "test" {
+  config_json = <<EOD
+{
+  "title": "Dashboard for report",
+  "uid": "report"
+}
+EOD
+  message     = "inital commit."
+}
+
+resource "grafana_dashboard" "test2" {
+  config_json = <<EOD
+{
+  "title": "Second dashboard for report",
+  "uid": "report2"
+}
+EOD
+  message     = "inital commit."
+}
+
+resource "grafana_report" "test" {
+  name       = "my report updated"
+  recipients = ["some@email.com"]
+  formats    = ["pdf", "csv", "image"]
+  schedule {
+    frequency = "weekly"
+    timezone  = "Europe/Berlin"
+  }
+
+  dashboards {
+    uid = grafana_dashboard.test.uid
+    time_range {
+      from = "now-1d"
+      to   = "now"
+    }
+    template_variables {
+      name   = "environment"
+      values = ["prod", "staging"]
+    }
+  }
+  dashboards {
+    uid = grafana_dashboard.test2.uid
+  }
+}
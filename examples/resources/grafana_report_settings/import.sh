@@ -0,0 +1 @@
+terraform import grafana_report_settings.settings {{org_id}}
@@ -0,0 +1,8 @@
+resource "grafana_report_settings" "settings" {
+  report_logo_url   = "https://grafana.com/static/assets/img/fav32.png"
+  email_logo_url    = "https://grafana.com/static/assets/img/fav32.png"
+  email_footer_mode = "sent-by"
+  email_footer_text = "Example Corp."
+  email_footer_link = "https://example.com/"
+  pdf_theme         = "dark"
+}
@@ -0,0 +1,22 @@
+data "grafana_synthetic_monitoring_probes" "main" {}
+
+resource "grafana_synthetic_monitoring_check" "multihttp" {
+  job     = "MultiHTTP defaults"
+  target  = "https://grafana.com"
+  enabled = false
+  probes = [
+    data.grafana_synthetic_monitoring_probes.main.probes.Atlanta,
+  ]
+  labels = {
+    foo = "bar"
+  }
+  settings {
+    multihttp {
+      entries {
+        request {
+          url = "https://grafana.com"
+        }
+      }
+    }
+  }
+}
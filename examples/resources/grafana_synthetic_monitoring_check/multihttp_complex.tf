@@ -0,0 +1,56 @@
+data "grafana_synthetic_monitoring_probes" "main" {}
+
+resource "grafana_synthetic_monitoring_check" "multihttp" {
+  job     = "MultiHTTP complex"
+  target  = "https://grafana.com/api/plugins"
+  enabled = false
+  probes = [
+    data.grafana_synthetic_monitoring_probes.main.probes.Frankfurt,
+    data.grafana_synthetic_monitoring_probes.main.probes.London,
+  ]
+  labels = {
+    foo = "baz"
+  }
+  settings {
+    multihttp {
+      entries {
+        request {
+          url = "https://grafana.com/api/plugins"
+          query_fields {
+            name  = "orderBy"
+            value = "weight"
+          }
+          headers {
+            name  = "Accept"
+            value = "application/json"
+          }
+        }
+        assertions {
+          type      = "TEXT"
+          subject   = "HTTP_STATUS_CODE"
+          condition = "EQUALS"
+          value     = "200"
+        }
+        variables {
+          type       = "JSON_PATH"
+          name       = "pluginSlug"
+          expression = "items.0.slug"
+        }
+      }
+      entries {
+        request {
+          method = "POST"
+          url    = "https://grafana.com/api/plugins/$${pluginSlug}/versions"
+          body {
+            content_type = "application/json"
+            payload      = jsonencode({ version = "latest" })
+          }
+        }
+        assertions {
+          type       = "JSON_PATH"
+          expression = "$.items"
+        }
+      }
+    }
+  }
+}
@@ -0,0 +1,9 @@
+resource "grafana_synthetic_monitoring_check" "probe_names" {
+  job         = "Probe names"
+  target      = "https://grafana.com"
+  enabled     = false
+  probe_names = ["Atlanta", "Frankfurt"]
+  settings {
+    http {}
+  }
+}
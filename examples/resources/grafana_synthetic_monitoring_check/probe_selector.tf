@@ -0,0 +1,12 @@
+resource "grafana_synthetic_monitoring_check" "probe_selector" {
+  job     = "Probe selector"
+  target  = "https://grafana.com"
+  enabled = false
+  probe_selector {
+    region     = "EMEA"
+    visibility = "public"
+  }
+  settings {
+    http {}
+  }
+}
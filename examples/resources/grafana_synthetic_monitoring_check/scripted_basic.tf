@@ -0,0 +1,25 @@
+data "grafana_synthetic_monitoring_probes" "main" {}
+
+resource "grafana_synthetic_monitoring_check" "scripted" {
+  job     = "Scripted defaults"
+  target  = "scripted"
+  enabled = false
+  probes = [
+    data.grafana_synthetic_monitoring_probes.main.probes.Atlanta,
+  ]
+  labels = {
+    foo = "bar"
+  }
+  settings {
+    scripted {
+      // `script = file("${path.module}/script.js")` can also be used to load the script from a file
+      script = <<-EOT
+        import http from 'k6/http';
+
+        export default function () {
+          http.get('https://grafana.com');
+        }
+      EOT
+    }
+  }
+}
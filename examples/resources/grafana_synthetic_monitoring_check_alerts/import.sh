@@ -0,0 +1 @@
+terraform import grafana_synthetic_monitoring_check_alerts.alerts {{check-id}}
@@ -0,0 +1,29 @@
+data "grafana_synthetic_monitoring_probes" "main" {}
+
+resource "grafana_synthetic_monitoring_check" "main" {
+  job     = "Check alerts"
+  target  = "https://grafana.com"
+  enabled = false
+  probes = [
+    data.grafana_synthetic_monitoring_probes.main.probes.Atlanta,
+  ]
+  settings {
+    http {}
+  }
+}
+
+resource "grafana_synthetic_monitoring_check_alerts" "main" {
+  check_id = grafana_synthetic_monitoring_check.main.id
+
+  alerts {
+    name      = "ProbeFailedExecutionsTooHigh"
+    threshold = 5
+    period    = "10m"
+  }
+  alerts {
+    name        = "HTTPRequestDurationTooHighAvg"
+    threshold   = 500
+    period      = "5m"
+    runbook_url = "https://example.com/runbooks/slow-requests"
+  }
+}
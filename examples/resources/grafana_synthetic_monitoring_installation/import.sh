@@ -0,0 +1 @@
+terraform import grafana_synthetic_monitoring_installation.sm_stack {{stack_id}}-{{metrics_instance_id}}-{{logs_instance_id}}:{{sm_access_token}}
@@ -0,0 +1,12 @@
+resource "grafana_synthetic_monitoring_probe" "main" {
+  name      = "Mauna Loa"
+  latitude  = 19.47948
+  longitude = -155.60282
+  region    = "AMER"
+  labels = {
+    type = "volcano"
+  }
+
+  // Change this value to generate a new auth_token
+  reset_token = "2022-01-01"
+}
@@ -0,0 +1,24 @@
+data "grafana_cloud_stack" "main" {
+  slug = "<stack-slug>"
+}
+
+resource "grafana_cloud_api_key" "metrics_publish" {
+  name           = "MetricsPublisherForSM"
+  role           = "MetricsPublisher"
+  cloud_org_slug = "<org-slug>"
+}
+
+resource "grafana_synthetic_monitoring_tenant" "main" {
+  metrics_remote {
+    name     = data.grafana_cloud_stack.main.prometheus_name
+    url      = data.grafana_cloud_stack.main.prometheus_remote_write_endpoint
+    username = data.grafana_cloud_stack.main.prometheus_user_id
+    password = grafana_cloud_api_key.metrics_publish.key
+  }
+  events_remote {
+    name     = data.grafana_cloud_stack.main.logs_name
+    url      = "${data.grafana_cloud_stack.main.logs_url}/loki/api/v1/push"
+    username = data.grafana_cloud_stack.main.logs_user_id
+    password = grafana_cloud_api_key.metrics_publish.key
+  }
+}
@@ -0,0 +1 @@
+terraform import grafana_user_preferences.preferences {{user_id}}
@@ -0,0 +1,13 @@
+resource "grafana_dashboard" "metrics" {
+  config_json = jsonencode({
+    title = "My Home Dashboard"
+    uid   = "my-home"
+  })
+}
+
+resource "grafana_user_preferences" "preferences" {
+  theme              = "dark"
+  timezone           = "Europe/Berlin"
+  week_start         = "monday"
+  home_dashboard_uid = grafana_dashboard.metrics.uid
+}
@@ -0,0 +1,114 @@
+package grafana
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+)
+
+// apiRequest performs a JSON request for endpoints (or fields) that are not yet supported by the API client libraries.
+// Errors are formatted the same way as the Grafana API client's, so that callers can handle them identically
+// (e.g. checking for a `status: 404` prefix).
+func apiRequest(ctx context.Context, httpClient *http.Client, baseURL string, headers http.Header, method, requestPath string, body, responseStruct interface{}) error {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return err
+	}
+	rel, err := url.Parse(requestPath)
+	if err != nil {
+		return err
+	}
+	u.Path = path.Join(u.Path, rel.Path)
+	u.RawQuery = rel.RawQuery
+
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewBuffer(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), reqBody)
+	if err != nil {
+		return err
+	}
+	for k, values := range headers {
+		for _, v := range values {
+			req.Header.Add(k, v)
+		}
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	bodyContents, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("status: %d, body: %v", resp.StatusCode, string(bodyContents))
+	}
+
+	if responseStruct == nil || len(bodyContents) == 0 {
+		return nil
+	}
+	return json.Unmarshal(bodyContents, responseStruct)
+}
+
+// cloudAPIRequest performs a request against the Grafana Cloud API
+func (c *client) cloudAPIRequest(ctx context.Context, method, requestPath string, body, responseStruct interface{}) error {
+	headers := http.Header{}
+	if c.gcloudAPIKey != "" {
+		headers.Set("Authorization", "Bearer "+c.gcloudAPIKey)
+	}
+	return apiRequest(ctx, c.gcloudHTTPClient, c.gcloudAPIURL, headers, method, requestPath, body, responseStruct)
+}
+
+// smAPIRequest performs a request against the Synthetic Monitoring API
+func (c *client) smAPIRequest(ctx context.Context, method, requestPath string, body, responseStruct interface{}) error {
+	headers := http.Header{}
+	if c.smToken == "" {
+		return fmt.Errorf("sm_access_token must be set to use the Synthetic Monitoring API")
+	}
+	headers.Set("Authorization", "Bearer "+c.smToken)
+	return apiRequest(ctx, c.smHTTPClient, c.smURL, headers, method, path.Join("/api/v1", requestPath), body, responseStruct)
+}
+
+// grafanaAPIRequest performs a request against the Grafana API, authenticated like the Grafana API client
+func (c *client) grafanaAPIRequest(ctx context.Context, method, requestPath string, body, responseStruct interface{}) error {
+	cfg := c.gapiConfig
+	headers := http.Header{}
+	switch {
+	case cfg.APIKey != "":
+		headers.Set("Authorization", "Bearer "+cfg.APIKey)
+	case cfg.BasicAuth != nil:
+		password, _ := cfg.BasicAuth.Password()
+		headers.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(cfg.BasicAuth.Username()+":"+password)))
+		if cfg.OrgID != 0 {
+			headers.Set("X-Grafana-Org-Id", strconv.FormatInt(cfg.OrgID, 10))
+		}
+	}
+	for k, v := range cfg.HTTPHeaders {
+		headers.Add(k, v)
+	}
+
+	httpClient := cfg.Client
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return apiRequest(ctx, httpClient, c.gapiURL, headers, method, requestPath, body, responseStruct)
+}
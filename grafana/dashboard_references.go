@@ -0,0 +1,142 @@
+package grafana
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// dashboardReference is a reference of a dashboard model to a datasource or a library panel.
+type dashboardReference struct {
+	// path is the JSON path of the reference in the dashboard model, such as `panels[0].targets[1].datasource.uid`.
+	path string
+	// value is the UID of the referenced datasource or library panel. Datasources can also be referenced by name.
+	value string
+}
+
+// builtinDatasources are the datasources that are referenced by dashboards without existing in the organization.
+var builtinDatasources = map[string]bool{
+	"default":         true,
+	"grafana":         true,
+	"-- Grafana --":   true,
+	"-- Mixed --":     true,
+	"-- Dashboard --": true,
+	// The server-side expressions datasource, and its legacy UID
+	"__expr__": true,
+	"-100":     true,
+}
+
+// dashboardReferences returns the datasources and library panels referenced by the panels (including the panels
+// of rows), targets, template variables and annotations of a dashboard model.
+func dashboardReferences(model map[string]interface{}) (datasources, libraryPanels []dashboardReference) {
+	addDatasource := func(value interface{}, path string) {
+		if ref, ok := datasourceReference(value, path); ok {
+			datasources = append(datasources, ref)
+		}
+	}
+
+	var walkPanels func(panels interface{}, path string)
+	walkPanels = func(panels interface{}, path string) {
+		list, _ := panels.([]interface{})
+		for i, p := range list {
+			panel, ok := p.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			panelPath := fmt.Sprintf("%s[%d]", path, i)
+			addDatasource(panel["datasource"], panelPath+".datasource")
+			targets, _ := panel["targets"].([]interface{})
+			for j, t := range targets {
+				if target, ok := t.(map[string]interface{}); ok {
+					addDatasource(target["datasource"], fmt.Sprintf("%s.targets[%d].datasource", panelPath, j))
+				}
+			}
+			if libraryPanel, ok := panel["libraryPanel"].(map[string]interface{}); ok {
+				if uid, _ := libraryPanel["uid"].(string); uid != "" {
+					libraryPanels = append(libraryPanels, dashboardReference{path: panelPath + ".libraryPanel.uid", value: uid})
+				}
+			}
+			walkPanels(panel["panels"], panelPath+".panels")
+		}
+	}
+	walkPanels(model["panels"], "panels")
+
+	for _, section := range []string{"templating", "annotations"} {
+		s, _ := model[section].(map[string]interface{})
+		list, _ := s["list"].([]interface{})
+		for i, item := range list {
+			if m, ok := item.(map[string]interface{}); ok {
+				addDatasource(m["datasource"], fmt.Sprintf("%s.list[%d].datasource", section, i))
+			}
+		}
+	}
+	return datasources, libraryPanels
+}
+
+// datasourceReference returns the reference of a `datasource` field, which is either the name (or UID) of the datasource,
+// or an object with its type and UID. Built-in datasources and datasources set by template variables aren't returned.
+func datasourceReference(value interface{}, path string) (dashboardReference, bool) {
+	ref := dashboardReference{path: path}
+	switch v := value.(type) {
+	case string:
+		ref.value = v
+	case map[string]interface{}:
+		ref.value, _ = v["uid"].(string)
+		ref.path += ".uid"
+	}
+	if ref.value == "" || strings.Contains(ref.value, "$") || builtinDatasources[ref.value] {
+		return ref, false
+	}
+	return ref, true
+}
+
+// validateDashboardReferences returns an error for each datasource or library panel referenced by the dashboard model
+// that doesn't exist in the organization.
+func (c *client) validateDashboardReferences(ctx context.Context, model map[string]interface{}) diag.Diagnostics {
+	datasourceRefs, libraryPanelRefs := dashboardReferences(model)
+	var diags diag.Diagnostics
+
+	if len(datasourceRefs) > 0 {
+		var datasources []struct {
+			UID  string `json:"uid"`
+			Name string `json:"name"`
+		}
+		if err := c.grafanaAPIRequest(ctx, http.MethodGet, "/api/datasources", nil, &datasources); err != nil {
+			return diag.Errorf("error listing datasources to validate the dashboard references: %s", err)
+		}
+		existing := map[string]bool{}
+		for _, ds := range datasources {
+			existing[ds.UID] = true
+			existing[ds.Name] = true
+		}
+		for _, ref := range datasourceRefs {
+			if !existing[ref.value] {
+				diags = append(diags, dashboardReferenceDiagnostic("datasource", ref))
+			}
+		}
+	}
+
+	for _, ref := range libraryPanelRefs {
+		if _, err := c.gapi.LibraryPanelByUID(ref.value); err != nil {
+			if !strings.HasPrefix(err.Error(), "status: 404") {
+				return append(diags, diag.Errorf("error getting library panel %q to validate the dashboard references: %s", ref.value, err)...)
+			}
+			diags = append(diags, dashboardReferenceDiagnostic("library panel", ref))
+		}
+	}
+
+	return diags
+}
+
+func dashboardReferenceDiagnostic(kind string, ref dashboardReference) diag.Diagnostic {
+	return diag.Diagnostic{
+		Severity:      diag.Error,
+		Summary:       fmt.Sprintf("The dashboard references a %s that doesn't exist: %q", kind, ref.value),
+		Detail:        fmt.Sprintf("The %s %q referenced at `%s` in the dashboard model was not found in the organization.", kind, ref.value, ref.path),
+		AttributePath: cty.GetAttrPath("config_json"),
+	}
+}
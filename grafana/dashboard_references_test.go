@@ -0,0 +1,63 @@
+package grafana
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	gapi "github.com/grafana/grafana-api-golang-client"
+)
+
+func TestValidateDashboardReferencesUnit(t *testing.T) {
+	IsUnitTest(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/datasources":
+			w.Write([]byte(`[{"uid": "prometheus-uid", "name": "Prometheus"}]`))
+		case "/api/library-elements/existing":
+			w.Write([]byte(`{"result": {"uid": "existing", "name": "existing"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	gapiClient, err := gapi.New(server.URL, gapi.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &client{gapi: gapiClient, gapiURL: server.URL, gapiConfig: &gapi.Config{}}
+
+	model, err := unmarshalDashboardConfigJSON(`{
+		"panels": [
+			{"datasource": {"type": "prometheus", "uid": "prometheus-uid"}, "targets": [{"datasource": {"uid": "missing-uid"}}]},
+			{"datasource": "Prometheus", "libraryPanel": {"uid": "existing"}},
+			{"type": "row", "panels": [{"datasource": "Missing", "libraryPanel": {"uid": "missing-panel"}}]},
+			{"datasource": {"uid": "${datasource}"}},
+			{"datasource": "-- Mixed --", "targets": [{"datasource": {"type": "__expr__", "uid": "__expr__"}}, {"datasource": {"uid": "-100"}}]}
+		],
+		"templating": {"list": [{"datasource": {"uid": "prometheus-uid"}}, {"datasource": {"uid": "missing-variable-uid"}}]},
+		"annotations": {"list": [{"datasource": "-- Grafana --"}, {"datasource": {"uid": "missing-annotation-uid"}}]}
+	}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	diags := c.validateDashboardReferences(context.Background(), model)
+	var details []string
+	for _, d := range diags {
+		details = append(details, d.Detail)
+	}
+	expected := []string{
+		"The datasource \"missing-uid\" referenced at `panels[0].targets[0].datasource.uid` in the dashboard model was not found in the organization.",
+		"The datasource \"Missing\" referenced at `panels[2].panels[0].datasource` in the dashboard model was not found in the organization.",
+		"The datasource \"missing-variable-uid\" referenced at `templating.list[1].datasource.uid` in the dashboard model was not found in the organization.",
+		"The datasource \"missing-annotation-uid\" referenced at `annotations.list[1].datasource.uid` in the dashboard model was not found in the organization.",
+		"The library panel \"missing-panel\" referenced at `panels[2].panels[0].libraryPanel.uid` in the dashboard model was not found in the organization.",
+	}
+	if !reflect.DeepEqual(details, expected) {
+		t.Errorf("expected diagnostics:\n%v\ngot:\n%v", expected, details)
+	}
+}
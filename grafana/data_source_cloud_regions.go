@@ -0,0 +1,99 @@
+package grafana
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DatasourceCloudRegions() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+Data source for retrieving all Grafana Cloud regions.
+
+* [Official documentation](https://grafana.com/docs/grafana-cloud/reference/cloud-api/#list-regions)
+`,
+		ReadContext: datasourceCloudRegionsRead,
+		Schema: map[string]*schema.Schema{
+			"slugs": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Slugs of all available regions. These can be used as the `region_slug` of a `grafana_cloud_stack`.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"regions": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "List of Grafana Cloud regions.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The region's numeric ID.",
+						},
+						"slug": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The region's slug. Used as `region_slug` when creating a stack.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The region's name.",
+						},
+						"description": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The region's description.",
+						},
+						"status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The region's status.",
+						},
+						"visibility": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The region's visibility.",
+						},
+						"synthetic_monitoring_api_url": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The Synthetic Monitoring API URL to use for stacks in this region.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func datasourceCloudRegionsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	regions, err := meta.(*client).getCloudRegions()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	slugs := make([]string, 0, len(regions))
+	regionList := make([]interface{}, 0, len(regions))
+	for _, region := range regions {
+		slugs = append(slugs, region.Slug)
+		regionList = append(regionList, map[string]interface{}{
+			"id":                           region.ID,
+			"slug":                         region.Slug,
+			"name":                         region.Name,
+			"description":                  region.Description,
+			"status":                       region.Status,
+			"visibility":                   region.Visibility,
+			"synthetic_monitoring_api_url": region.SyntheticMonitoringAPIURL,
+		})
+	}
+
+	d.SetId("cloud_regions")
+	d.Set("slugs", slugs)
+	d.Set("regions", regionList)
+
+	return nil
+}
@@ -0,0 +1,28 @@
+package grafana
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDatasourceCloudRegions_Basic(t *testing.T) {
+	t.Parallel()
+	CheckCloudAPITestsEnabled(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "grafana_cloud_regions" "test" {}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.grafana_cloud_regions.test", "id", "cloud_regions"),
+					resource.TestCheckTypeSetElemAttr("data.grafana_cloud_regions.test", "slugs.*", "eu"),
+					resource.TestCheckTypeSetElemAttr("data.grafana_cloud_regions.test", "slugs.*", "us"),
+					resource.TestCheckResourceAttrSet("data.grafana_cloud_regions.test", "regions.0.slug"),
+					resource.TestCheckResourceAttrSet("data.grafana_cloud_regions.test", "regions.0.name"),
+				),
+			},
+		},
+	})
+}
@@ -24,19 +24,19 @@ available at “https://<stack_slug>.grafana.net".`,
 				Computed:    true,
 				Description: "The region this stack is deployed to.",
 			},
-			"wait_for_readiness": nil,
+			"wait_for_readiness":  nil,
+			"wait_for_components": nil,
+			"delete_protection":   nil,
 		}),
 	}
 }
 
 func datasourceCloudStackRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*client).gcloudapi
-
 	var diags diag.Diagnostics
 
 	slug := d.Get("slug").(string)
 
-	stack, err := client.StackBySlug(slug)
+	stack, err := meta.(*client).getCloudStack(ctx, slug)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -33,6 +33,12 @@ func TestAccDatasourceCloudStack_Basic(t *testing.T) {
 					resource.TestCheckResourceAttrSet("data.grafana_cloud_stack.test", "prometheus_url"),
 					resource.TestCheckResourceAttrSet("data.grafana_cloud_stack.test", "prometheus_user_id"),
 					resource.TestCheckResourceAttrSet("data.grafana_cloud_stack.test", "alertmanager_user_id"),
+					resource.TestCheckResourceAttrSet("data.grafana_cloud_stack.test", "graphite_url"),
+					resource.TestCheckResourceAttrSet("data.grafana_cloud_stack.test", "graphite_user_id"),
+					resource.TestCheckResourceAttrSet("data.grafana_cloud_stack.test", "traces_url"),
+					resource.TestCheckResourceAttrSet("data.grafana_cloud_stack.test", "traces_user_id"),
+					resource.TestCheckResourceAttrPair("data.grafana_cloud_stack.test", "traces_url", "grafana_cloud_stack.test", "traces_url"),
+					resource.TestCheckResourceAttrPair("data.grafana_cloud_stack.test", "profiles_url", "grafana_cloud_stack.test", "profiles_url"),
 				),
 			},
 		},
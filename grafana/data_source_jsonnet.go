@@ -0,0 +1,93 @@
+package grafana
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/google/go-jsonnet"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DatasourceJsonnet() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+Renders a dashboard model written in Jsonnet, for example with [Grafonnet](https://github.com/grafana/grafonnet-lib), so that it can be passed to ` + "`grafana_dashboard`" + `.
+The Jsonnet code is evaluated by the provider, and doesn't require the ` + "`jsonnet`" + ` command.
+
+* [Jsonnet](https://jsonnet.org/)
+`,
+		ReadContext: dataSourceJsonnetRead,
+		Schema: map[string]*schema.Schema{
+			"source": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"source", "file"},
+				Description:  "The Jsonnet code to evaluate. Either this or `file` must be set.",
+			},
+			"file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The path of the Jsonnet file to evaluate. Imports are resolved relative to the file first. Either this or `source` must be set.",
+			},
+			"library_paths": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Directories in which imports are searched, in order, such as the `vendor` directory of jsonnet-bundler.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"ext_vars": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "External variables, available to the Jsonnet code with `std.extVar()`, as strings.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"ext_code": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "External variables, available to the Jsonnet code with `std.extVar()`, as Jsonnet code.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"config_json": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Description: "The rendered dashboard model JSON, without the fields managed by Grafana, " +
+					"the same way as the `config_json` attribute of `grafana_dashboard`.",
+			},
+		},
+	}
+}
+
+func dataSourceJsonnetRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vm := jsonnet.MakeVM()
+	vm.Importer(&jsonnet.FileImporter{JPaths: listToStringSlice(d.Get("library_paths").([]interface{}))})
+	for k, v := range d.Get("ext_vars").(map[string]interface{}) {
+		vm.ExtVar(k, v.(string))
+	}
+	for k, v := range d.Get("ext_code").(map[string]interface{}) {
+		vm.ExtCode(k, v.(string))
+	}
+
+	var output string
+	var err error
+	if file := d.Get("file").(string); file != "" {
+		output, err = vm.EvaluateFile(file)
+	} else {
+		output, err = vm.EvaluateAnonymousSnippet("source.jsonnet", d.Get("source").(string))
+	}
+	if err != nil {
+		return diag.Errorf("error evaluating Jsonnet: %s", err)
+	}
+
+	dashboardJSON, err := unmarshalDashboardConfigJSON(output)
+	if err != nil {
+		return diag.Errorf("the Jsonnet code must evaluate to a dashboard model object: %s", err)
+	}
+	configJSON := normalizeDashboardConfigJSON(dashboardJSON)
+
+	d.SetId(fmt.Sprintf("%x", sha256.Sum256([]byte(configJSON))))
+	d.Set("config_json", configJSON)
+
+	return nil
+}
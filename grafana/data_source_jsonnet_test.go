@@ -0,0 +1,101 @@
+package grafana
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	gapi "github.com/grafana/grafana-api-golang-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestAccDatasourceJsonnet(t *testing.T) {
+	CheckOSSTestsEnabled(t)
+
+	var dashboard gapi.Dashboard
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccDashboardCheckDestroy(&dashboard),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExample(t, "data-sources/grafana_jsonnet/data-source.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDashboardCheckExists("grafana_dashboard.jsonnet", &dashboard),
+					resource.TestCheckResourceAttr("grafana_dashboard.jsonnet", "uid", "jsonnet"),
+					resource.TestCheckResourceAttrPair("data.grafana_jsonnet.dashboard", "config_json", "grafana_dashboard.jsonnet", "config_json"),
+				),
+			},
+		},
+	})
+}
+
+func TestDatasourceJsonnetUnit(t *testing.T) {
+	IsUnitTest(t)
+
+	dir := t.TempDir()
+	libDir := filepath.Join(dir, "vendor")
+	if err := os.Mkdir(libDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(libDir, "dashboard.libsonnet"), []byte(`{ new(title):: { title: title, id: 12, version: 3, panels: [] } }`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(dir, "main.jsonnet")
+	if err := os.WriteFile(file, []byte(`(import 'dashboard.libsonnet').new(std.extVar('title')) + { editable: std.extVar('editable') }`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name     string
+		raw      map[string]interface{}
+		expected string
+		err      string
+	}{
+		{
+			name: "file with library paths",
+			raw: map[string]interface{}{
+				"file":          file,
+				"library_paths": []interface{}{libDir},
+				"ext_vars":      map[string]interface{}{"title": "From file"},
+				"ext_code":      map[string]interface{}{"editable": "false"},
+			},
+			expected: `{"editable":false,"panels":[],"title":"From file"}`,
+		},
+		{
+			name:     "source",
+			raw:      map[string]interface{}{"source": `{ title: 'From source', id: 1 }`},
+			expected: `{"title":"From source"}`,
+		},
+		{
+			name: "missing import",
+			raw:  map[string]interface{}{"file": file},
+			err:  "error evaluating Jsonnet",
+		},
+		{
+			name: "not an object",
+			raw:  map[string]interface{}{"source": `[1, 2]`},
+			err:  "the Jsonnet code must evaluate to a dashboard model object",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, DatasourceJsonnet().Schema, tc.raw)
+			diags := dataSourceJsonnetRead(context.Background(), d, nil)
+			if tc.err != "" {
+				if !diags.HasError() || !strings.HasPrefix(diags[0].Summary, tc.err) {
+					t.Fatalf("expected error %q, got %v", tc.err, diags)
+				}
+				return
+			}
+			if diags.HasError() {
+				t.Fatal(diags)
+			}
+			if actual := d.Get("config_json").(string); actual != tc.expected {
+				t.Errorf("expected %s, got %s", tc.expected, actual)
+			}
+		})
+	}
+}
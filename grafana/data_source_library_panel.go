@@ -22,6 +22,7 @@ func DatasourceLibraryPanel() *schema.Resource {
 				Optional:    true,
 				Description: "The unique identifier (UID) of the library panel.",
 			},
+			"force_delete": nil,
 		}),
 	}
 }
@@ -0,0 +1,123 @@
+package grafana
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func DatasourceLibraryPanels() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+Data source for retrieving the library panels, optionally filtered by folder, panel type or search string.
+
+* [Official documentation](https://grafana.com/docs/grafana/latest/panels/panel-library/)
+* [HTTP API](https://grafana.com/docs/grafana/latest/http_api/library_element/)
+`,
+		ReadContext: dataSourceLibraryPanelsRead,
+		Schema: map[string]*schema.Schema{
+			"folder_ids": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Numerical IDs of Grafana folders containing library panels. Specify to filter for library panels by folder (eg. `[0]` for General folder), or leave blank to get library panels in all folders.",
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+			},
+			"types": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Panel types to filter for, eg. `[\"timeseries\", \"stat\"]`. Leave blank to get library panels of all types.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"search": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "String to search for in the names and descriptions of the library panels.",
+			},
+			"panels": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"uid": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"folder_id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"folder_uid": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"model_json": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceLibraryPanelsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*client)
+	params := url.Values{}
+
+	if list, ok := d.GetOk("folder_ids"); ok {
+		ids := make([]string, 0, len(list.([]interface{})))
+		for _, elem := range list.([]interface{}) {
+			ids = append(ids, fmt.Sprint(elem))
+		}
+		params.Set("folderFilter", strings.Join(ids, ","))
+	}
+	if list, ok := d.GetOk("types"); ok {
+		params.Set("typeFilter", strings.Join(listToStringSlice(list.([]interface{})), ","))
+	}
+	if search, ok := d.GetOk("search"); ok {
+		params.Set("searchString", search.(string))
+	}
+
+	d.SetId(hashDashboardSearchParameters(params))
+
+	results, err := client.searchLibraryPanels(ctx, params)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	panels := make([]map[string]interface{}, len(results))
+	for i, result := range results {
+		panels[i] = map[string]interface{}{
+			"uid":         result.UID,
+			"name":        result.Name,
+			"description": result.Description,
+			"type":        result.Type,
+			"folder_id":   result.Folder,
+			"folder_uid":  result.Meta.FolderUID,
+			"model_json":  normalizeLibraryPanelModelJSON(result.Model),
+		}
+	}
+
+	if err := d.Set("panels", panels); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
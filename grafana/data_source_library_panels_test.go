@@ -0,0 +1,29 @@
+package grafana
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDatasourceLibraryPanels(t *testing.T) {
+	CheckOSSTestsEnabled(t)
+	CheckOSSTestsSemver(t, ">=8.0.0")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExample(t, "data-sources/grafana_library_panels/data-source.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.grafana_library_panels.folder", "panels.#", "2"),
+					resource.TestCheckResourceAttr("data.grafana_library_panels.text", "panels.#", "1"),
+					resource.TestCheckResourceAttr("data.grafana_library_panels.text", "panels.0.name", "library panels text"),
+					resource.TestCheckResourceAttr("data.grafana_library_panels.text", "panels.0.type", "text"),
+					resource.TestCheckResourceAttrPair("data.grafana_library_panels.text", "panels.0.uid", "grafana_library_panel.text", "uid"),
+					resource.TestCheckResourceAttrPair("data.grafana_library_panels.text", "panels.0.folder_uid", "grafana_folder.test", "uid"),
+				),
+			},
+		},
+	})
+}
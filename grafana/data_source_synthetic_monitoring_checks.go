@@ -0,0 +1,162 @@
+package grafana
+
+import (
+	"context"
+	"regexp"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func DatasourceSyntheticMonitoringChecks() *schema.Resource {
+	return &schema.Resource{
+		Description: "Data source for retrieving all checks, optionally filtered.",
+		ReadContext: dataSourceSyntheticMonitoringChecksRead,
+		Schema: map[string]*schema.Schema{
+			"job": {
+				Description:  "If set, only checks whose job matches this regular expression are returned.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"target": {
+				Description:  "If set, only checks whose target matches this regular expression are returned.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"labels": {
+				Description: "If set, only checks that have all of these labels (with the same values) are returned.",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"type": {
+				Description:  "If set, only checks of this type are returned. One of `dns`, `http`, `ping`, `tcp`, `traceroute`, `multihttp`, `scripted`.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"dns", "http", "ping", "tcp", "traceroute", "multihttp", "scripted"}, false),
+			},
+			"enabled": {
+				Description: "If set, only checks that are enabled (`true`) or disabled (`false`) are returned.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+			},
+			"checks": {
+				Description: "The checks matching the filters, sorted by ID.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: cloneResourceSchemaForDatasource(ResourceSyntheticMonitoringCheck(), map[string]*schema.Schema{
+						"id": {
+							Description: "The ID of the check.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"type": {
+							Description: "The type of the check, which is the name of its settings block.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"probes": {
+							Description: "List of probe location IDs where this target is checked from.",
+							Type:        schema.TypeSet,
+							Computed:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeInt,
+							},
+						},
+						"probe_names":    nil,
+						"probe_selector": nil,
+						"settings": {
+							Description: "Check settings, with exactly one nested block.",
+							Type:        schema.TypeSet,
+							Computed:    true,
+							Elem:        syntheticMonitoringCheckSettings,
+						},
+					}),
+				},
+			},
+		},
+	}
+}
+
+func dataSourceSyntheticMonitoringChecksRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*client)
+	chks, err := c.smListChecks(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	sort.Slice(chks, func(i, j int) bool { return chks[i].Id < chks[j].Id })
+
+	var jobRegexp, targetRegexp *regexp.Regexp
+	if v, ok := d.GetOk("job"); ok {
+		jobRegexp = regexp.MustCompile(v.(string))
+	}
+	if v, ok := d.GetOk("target"); ok {
+		targetRegexp = regexp.MustCompile(v.(string))
+	}
+	labelFilter := d.Get("labels").(map[string]interface{})
+	typeFilter := d.Get("type").(string)
+	enabledFilter, filterEnabled := d.GetOkExists("enabled") //nolint:staticcheck // the only way to tell an unset bool from false
+
+	checks := []interface{}{}
+	for _, chk := range chks {
+		if jobRegexp != nil && !jobRegexp.MatchString(chk.Job) {
+			continue
+		}
+		if targetRegexp != nil && !targetRegexp.MatchString(chk.Target) {
+			continue
+		}
+		chkType := smCheckType(chk.Settings)
+		if typeFilter != "" && chkType != typeFilter {
+			continue
+		}
+		if filterEnabled && chk.Enabled != enabledFilter.(bool) {
+			continue
+		}
+		labels := make(map[string]interface{}, len(chk.Labels))
+		for _, l := range chk.Labels {
+			labels[l.Name] = l.Value
+		}
+		labelsMatch := true
+		for name, value := range labelFilter {
+			if labels[name] != value {
+				labelsMatch = false
+				break
+			}
+		}
+		if !labelsMatch {
+			continue
+		}
+
+		probes := make([]interface{}, 0, len(chk.Probes))
+		for _, p := range chk.Probes {
+			probes = append(probes, int(p))
+		}
+		checks = append(checks, map[string]interface{}{
+			"id":                 chk.Id,
+			"tenant_id":          chk.TenantId,
+			"type":               chkType,
+			"job":                chk.Job,
+			"target":             chk.Target,
+			"frequency":          chk.Frequency,
+			"timeout":            chk.Timeout,
+			"enabled":            chk.Enabled,
+			"alert_sensitivity":  chk.AlertSensitivity,
+			"basic_metrics_only": chk.BasicMetricsOnly,
+			"probes":             probes,
+			"labels":             labels,
+			"settings":           flattenCheckSettings(chk.Settings),
+		})
+	}
+
+	d.SetId("checks")
+	d.Set("checks", checks)
+
+	return nil
+}
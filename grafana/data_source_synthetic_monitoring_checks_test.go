@@ -0,0 +1,31 @@
+package grafana
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceSyntheticMonitoringChecks(t *testing.T) {
+	CheckCloudInstanceTestsEnabled(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExample(t, "data-sources/grafana_synthetic_monitoring_checks/data-source.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.grafana_synthetic_monitoring_checks.team", "checks.#", "1"),
+					resource.TestCheckResourceAttrPair("data.grafana_synthetic_monitoring_checks.team", "checks.0.id", "grafana_synthetic_monitoring_check.http", "id"),
+					resource.TestCheckResourceAttr("data.grafana_synthetic_monitoring_checks.team", "checks.0.type", "http"),
+					resource.TestCheckResourceAttr("data.grafana_synthetic_monitoring_checks.team", "checks.0.job", "Checks data source"),
+					resource.TestCheckResourceAttr("data.grafana_synthetic_monitoring_checks.team", "checks.0.target", "https://grafana.com"),
+					resource.TestCheckResourceAttr("data.grafana_synthetic_monitoring_checks.team", "checks.0.enabled", "false"),
+					resource.TestCheckResourceAttr("data.grafana_synthetic_monitoring_checks.team", "checks.0.probes.0", "1"),
+					resource.TestCheckResourceAttr("data.grafana_synthetic_monitoring_checks.team", "checks.0.labels.team", "checks-data-source"),
+					resource.TestCheckResourceAttr("data.grafana_synthetic_monitoring_checks.team", "checks.0.settings.0.http.0.method", "GET"),
+				),
+			},
+		},
+	})
+}
@@ -20,7 +20,8 @@ func DatasourceSyntheticMonitoringProbe() *schema.Resource {
 				Type:        schema.TypeString,
 				Required:    true,
 			},
-			"auth_token": nil,
+			"auth_token":  nil,
+			"reset_token": nil,
 		}),
 	}
 }
@@ -51,6 +52,9 @@ func dataSourceSyntheticMonitoringProbeRead(ctx context.Context, d *schema.Resou
 	d.Set("longitude", prb.Longitude)
 	d.Set("region", prb.Region)
 	d.Set("public", prb.Public)
+	d.Set("online", prb.Online)
+	d.Set("last_seen", probeLastSeen(&prb))
+	d.Set("version", prb.Version)
 
 	// Convert []sm.Label into a map before set.
 	labels := make(map[string]string, len(prb.Labels))
@@ -16,6 +16,9 @@ func TestAccDataSourceSyntheticMonitoringProbe(t *testing.T) {
 				Config: testAccExample(t, "data-sources/grafana_synthetic_monitoring_probe/data-source.tf"),
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr("data.grafana_synthetic_monitoring_probe.atlanta", "name", "Atlanta"),
+					resource.TestCheckResourceAttr("data.grafana_synthetic_monitoring_probe.atlanta", "online", "true"),
+					resource.TestCheckResourceAttrSet("data.grafana_synthetic_monitoring_probe.atlanta", "last_seen"),
+					resource.TestCheckResourceAttrSet("data.grafana_synthetic_monitoring_probe.atlanta", "version"),
 				),
 			},
 		},
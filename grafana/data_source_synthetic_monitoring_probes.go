@@ -26,6 +26,50 @@ func DatasourceSyntheticMonitoringProbes() *schema.Resource {
 					Type: schema.TypeInt,
 				},
 			},
+			"details": {
+				Description: "List of probes with their status.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Description: "The ID of the probe.",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
+						"name": {
+							Description: "Name of the probe.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"region": {
+							Description: "Region of the probe.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"public": {
+							Description: "Whether the probe is public.",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+						"online": {
+							Description: "Whether the probe is currently connected to the Synthetic Monitoring API.",
+							Type:        schema.TypeBool,
+							Computed:    true,
+						},
+						"last_seen": {
+							Description: "Time (RFC3339) at which the probe's `online` status last changed. For an offline probe, this is the last time it was seen.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"version": {
+							Description: "Version of the Synthetic Monitoring Agent run by the probe.",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -39,14 +83,25 @@ func dataSourceSyntheticMonitoringProbesRead(ctx context.Context, d *schema.Reso
 	}
 
 	probes := make(map[string]interface{}, len(prbs))
-	for _, p := range prbs {
+	details := make([]interface{}, 0, len(prbs))
+	for i, p := range prbs {
 		if !p.Deprecated || !d.Get("filter_deprecated").(bool) {
 			probes[p.Name] = p.Id
+			details = append(details, map[string]interface{}{
+				"id":        p.Id,
+				"name":      p.Name,
+				"region":    p.Region,
+				"public":    p.Public,
+				"online":    p.Online,
+				"last_seen": probeLastSeen(&prbs[i]),
+				"version":   p.Version,
+			})
 		}
 	}
 
 	d.SetId("probes")
 	d.Set("probes", probes)
+	d.Set("details", details)
 
 	return diags
 }
@@ -14,7 +14,15 @@ func TestAccDataSourceSyntheticMonitoringProbes(t *testing.T) {
 		Steps: []resource.TestStep{
 			{
 				Config: testAccExample(t, "data-sources/grafana_synthetic_monitoring_probes/data-source.tf"),
-				Check:  resource.TestCheckResourceAttr("data.grafana_synthetic_monitoring_probes.main", "probes.Atlanta", "1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.grafana_synthetic_monitoring_probes.main", "probes.Atlanta", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.grafana_synthetic_monitoring_probes.main", "details.*", map[string]string{
+						"id":     "1",
+						"name":   "Atlanta",
+						"public": "true",
+						"online": "true",
+					}),
+				),
 			},
 			{
 				Config: testAccExample(t, "data-sources/grafana_synthetic_monitoring_probes/with-deprecated.tf"),
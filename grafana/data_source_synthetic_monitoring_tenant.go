@@ -0,0 +1,131 @@
+package grafana
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	sm "github.com/grafana/synthetic-monitoring-agent/pkg/pb/synthetic_monitoring"
+)
+
+func DatasourceSyntheticMonitoringTenant() *schema.Resource {
+	remote := &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Description: "Name of the remote.",
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+				"url": {
+					Description: "URL of the remote.",
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+				"username": {
+					Description: "Username used to authenticate with the remote.",
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+			},
+		},
+	}
+	metricsRemote, eventsRemote := *remote, *remote
+	metricsRemote.Description = "Where the metrics produced by the checks are sent."
+	eventsRemote.Description = "Where the logs produced by the checks are sent."
+
+	return &schema.Resource{
+		Description: `
+Data source for retrieving the Synthetic Monitoring tenant the provider's ` + "`sm_access_token`" + ` belongs to,
+along with its limits and current usage. The usage can be compared to the limits (for example in a
+` + "`precondition`" + `) to catch quota exhaustion before creating checks or probes.
+`,
+		ReadContext: dataSourceSyntheticMonitoringTenantRead,
+		Schema: cloneResourceSchemaForDatasource(ResourceSyntheticMonitoringTenant(), map[string]*schema.Schema{
+			"metrics_remote": &metricsRemote,
+			"events_remote":  &eventsRemote,
+			"max_checks": {
+				Description: "Maximum number of checks of the tenant.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"max_probes": {
+				Description: "Maximum number of private probes of the tenant.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"max_metric_labels": {
+				Description: "Maximum number of custom labels a check can add to its metrics.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"max_log_labels": {
+				Description: "Maximum number of custom labels a check can add to its logs.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"checks_count": {
+				Description: "Current number of checks of the tenant.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"probes_count": {
+				Description: "Current number of private probes of the tenant.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+		}),
+	}
+}
+
+func dataSourceSyntheticMonitoringTenantRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*client)
+	tenant, err := c.smapi.GetTenant(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	limits, err := c.smGetTenantLimits(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	checks, err := c.smListChecks(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	probes, err := c.smapi.ListProbes(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	privateProbes := 0
+	for _, p := range probes {
+		if !p.Public {
+			privateProbes++
+		}
+	}
+
+	d.SetId(strconv.FormatInt(tenant.Id, 10))
+	d.Set("org_id", tenant.OrgId)
+	d.Set("stack_id", tenant.StackId)
+	d.Set("status", tenant.Status.String())
+	d.Set("reason", tenant.Reason)
+	for key, remote := range map[string]*sm.RemoteInfo{"metrics_remote": tenant.MetricsRemote, "events_remote": tenant.EventsRemote} {
+		remotes := []interface{}{}
+		for _, r := range flattenTenantRemote(remote, nil) {
+			delete(r.(map[string]interface{}), "password")
+			remotes = append(remotes, r)
+		}
+		d.Set(key, remotes)
+	}
+	d.Set("max_checks", limits.MaxChecks)
+	d.Set("max_probes", limits.MaxProbes)
+	d.Set("max_metric_labels", limits.MaxMetricLabels)
+	d.Set("max_log_labels", limits.MaxLogLabels)
+	d.Set("checks_count", len(checks))
+	d.Set("probes_count", privateProbes)
+
+	return nil
+}
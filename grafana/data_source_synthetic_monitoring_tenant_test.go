@@ -0,0 +1,30 @@
+package grafana
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceSyntheticMonitoringTenant(t *testing.T) {
+	CheckCloudInstanceTestsEnabled(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExample(t, "data-sources/grafana_synthetic_monitoring_tenant/data-source.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.grafana_synthetic_monitoring_tenant.main", "id"),
+					resource.TestCheckResourceAttrSet("data.grafana_synthetic_monitoring_tenant.main", "stack_id"),
+					resource.TestCheckResourceAttr("data.grafana_synthetic_monitoring_tenant.main", "status", "ACTIVE"),
+					resource.TestCheckResourceAttrSet("data.grafana_synthetic_monitoring_tenant.main", "metrics_remote.0.url"),
+					resource.TestCheckResourceAttrSet("data.grafana_synthetic_monitoring_tenant.main", "events_remote.0.url"),
+					resource.TestCheckResourceAttrSet("data.grafana_synthetic_monitoring_tenant.main", "max_checks"),
+					resource.TestCheckResourceAttrSet("data.grafana_synthetic_monitoring_tenant.main", "checks_count"),
+					resource.TestCheckResourceAttrSet("data.grafana_synthetic_monitoring_tenant.main", "probes_count"),
+				),
+			},
+		},
+	})
+}
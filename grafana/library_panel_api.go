@@ -0,0 +1,45 @@
+package grafana
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	gapi "github.com/grafana/grafana-api-golang-client"
+)
+
+// The Grafana API client lists all library panels without filters.
+// libraryPanelSearch sends the search to the Grafana API directly.
+
+type libraryPanelSearchResponse struct {
+	Result struct {
+		TotalCount int64               `json:"totalCount"`
+		Page       int64               `json:"page"`
+		PerPage    int64               `json:"perPage"`
+		Elements   []gapi.LibraryPanel `json:"elements"`
+	} `json:"result"`
+}
+
+// libraryPanelSearchPageSize is the number of library panels requested per page.
+const libraryPanelSearchPageSize = 100
+
+// searchLibraryPanels returns all library panels matching the search parameters, following the pages of results.
+func (c *client) searchLibraryPanels(ctx context.Context, params url.Values) ([]gapi.LibraryPanel, error) {
+	// Library elements of kind 1 are panels
+	params.Set("kind", "1")
+	params.Set("perPage", strconv.Itoa(libraryPanelSearchPageSize))
+
+	var panels []gapi.LibraryPanel
+	for page := 1; ; page++ {
+		params.Set("page", strconv.Itoa(page))
+		var resp libraryPanelSearchResponse
+		if err := c.grafanaAPIRequest(ctx, http.MethodGet, "/api/library-elements?"+params.Encode(), nil, &resp); err != nil {
+			return nil, err
+		}
+		panels = append(panels, resp.Result.Elements...)
+		if len(resp.Result.Elements) == 0 || int64(len(panels)) >= resp.Result.TotalCount {
+			return panels, nil
+		}
+	}
+}
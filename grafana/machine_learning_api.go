@@ -0,0 +1,207 @@
+package grafana
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/grafana/machine-learning-go-client/mlapi"
+	"github.com/hashicorp/go-cleanhttp"
+)
+
+// mlClient is the Machine Learning API client, extended with the job fields and ML resources it doesn't support yet.
+// The requests for them are sent to the ML plugin with the same configuration as the client's.
+type mlClient struct {
+	*mlapi.Client
+	url    string
+	config mlapi.Config
+
+	// The algorithms supported by the ML plugin, fetched once for all the jobs of a plan.
+	algorithmsMu sync.Mutex
+	algorithms   []string
+}
+
+func newMLClient(url string, cfg mlapi.Config) (*mlClient, error) {
+	c, err := mlapi.New(url, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &mlClient{Client: c, url: url, config: cfg}, nil
+}
+
+func (c *mlClient) request(ctx context.Context, method, requestPath string, body, responseStruct interface{}) error {
+	headers := http.Header{}
+	switch {
+	case c.config.BearerToken != "":
+		headers.Set("Authorization", "Bearer "+c.config.BearerToken)
+	case c.config.BasicAuth != nil:
+		password, _ := c.config.BasicAuth.Password()
+		headers.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(c.config.BasicAuth.Username()+":"+password)))
+	}
+	httpClient := c.config.Client
+	if httpClient == nil {
+		httpClient = cleanhttp.DefaultClient()
+	}
+	return apiRequest(ctx, httpClient, c.url, headers, method, requestPath, body, responseStruct)
+}
+
+// mlJob is a mlapi.Job that can also reference its datasource by UID and list its linked holidays.
+// The datasource ID is only sent when set, as the API rejects jobs referencing the datasource twice.
+type mlJob struct {
+	mlapi.Job
+	DatasourceID  uint     `json:"datasourceId,omitempty"`
+	DatasourceUID string   `json:"datasourceUid,omitempty"`
+	Holidays      []string `json:"holidays,omitempty"`
+}
+
+// mlResponse is the envelope of the ML API responses.
+type mlResponse struct {
+	Status string      `json:"status"`
+	Data   interface{} `json:"data"`
+}
+
+// NewJob creates a machine learning job and schedules a training.
+func (c *mlClient) NewJob(ctx context.Context, job mlJob) (mlJob, error) {
+	var result mlJob
+	err := c.request(ctx, http.MethodPost, "/manage/api/v1/jobs", job, &mlResponse{Data: &result})
+	return result, err
+}
+
+// Job fetches an existing machine learning job.
+func (c *mlClient) Job(ctx context.Context, id string) (mlJob, error) {
+	var result mlJob
+	err := c.request(ctx, http.MethodGet, "/manage/api/v1/jobs/"+id, nil, &mlResponse{Data: &result})
+	return result, err
+}
+
+// UpdateJob updates a machine learning job. A new training will be scheduled as part of updating.
+func (c *mlClient) UpdateJob(ctx context.Context, job mlJob) (mlJob, error) {
+	id := job.ID
+	// Clear the ID before sending otherwise validation fails.
+	job.ID = ""
+	var result mlJob
+	err := c.request(ctx, http.MethodPost, "/manage/api/v1/jobs/"+id, job, &mlResponse{Data: &result})
+	return result, err
+}
+
+// JobAlgorithms returns the names of the algorithms supported by the ML plugin for jobs.
+func (c *mlClient) JobAlgorithms(ctx context.Context) ([]string, error) {
+	c.algorithmsMu.Lock()
+	defer c.algorithmsMu.Unlock()
+	if c.algorithms != nil {
+		return c.algorithms, nil
+	}
+
+	var result []struct {
+		Name string `json:"name"`
+	}
+	if err := c.request(ctx, http.MethodGet, "/manage/api/v1/algorithms", nil, &mlResponse{Data: &result}); err != nil {
+		return nil, err
+	}
+	algorithms := make([]string, 0, len(result))
+	for _, a := range result {
+		algorithms = append(algorithms, a.Name)
+	}
+	c.algorithms = algorithms
+	return algorithms, nil
+}
+
+// LinkHolidaysToJob replaces the holidays linked to the job.
+func (c *mlClient) LinkHolidaysToJob(ctx context.Context, jobID string, holidayIDs []string) error {
+	body := map[string][]string{"holidays": holidayIDs}
+	return c.request(ctx, http.MethodPost, "/manage/api/v1/jobs/"+jobID+"/holidays", body, nil)
+}
+
+// mlHoliday is a period of time excluded from the training of the jobs it is linked to.
+type mlHoliday struct {
+	ID            string                  `json:"id,omitempty"`
+	Name          string                  `json:"name"`
+	Description   string                  `json:"description"`
+	ICalURL       string                  `json:"iCalUrl,omitempty"`
+	ICalTimeZone  string                  `json:"iCalTimeZone,omitempty"`
+	CustomPeriods []mlHolidayCustomPeriod `json:"customPeriods,omitempty"`
+}
+
+type mlHolidayCustomPeriod struct {
+	Name      string    `json:"name"`
+	StartTime time.Time `json:"startTime"`
+	EndTime   time.Time `json:"endTime"`
+}
+
+// mlOutlierDetector detects series behaving differently from the others returned by a query.
+type mlOutlierDetector struct {
+	ID             string                     `json:"id,omitempty"`
+	Name           string                     `json:"name"`
+	Metric         string                     `json:"metric"`
+	Description    string                     `json:"description"`
+	GrafanaURL     string                     `json:"grafanaUrl"`
+	DatasourceID   uint                       `json:"datasourceId,omitempty"`
+	DatasourceUID  string                     `json:"datasourceUid,omitempty"`
+	DatasourceType string                     `json:"datasourceType"`
+	QueryParams    map[string]interface{}     `json:"queryParams"`
+	Interval       uint                       `json:"interval"`
+	Algorithm      mlOutlierDetectorAlgorithm `json:"algorithm"`
+}
+
+type mlOutlierDetectorAlgorithm struct {
+	Name        string                            `json:"name"`
+	Sensitivity float64                           `json:"sensitivity"`
+	Config      *mlOutlierDetectorAlgorithmConfig `json:"config,omitempty"`
+}
+
+type mlOutlierDetectorAlgorithmConfig struct {
+	Epsilon float64 `json:"epsilon"`
+}
+
+// Algorithms supported by the ML plugin for outlier detectors.
+var mlOutlierDetectorAlgorithms = []string{"dbscan", "mad"}
+
+func (c *mlClient) NewHoliday(ctx context.Context, holiday mlHoliday) (mlHoliday, error) {
+	var result mlHoliday
+	err := c.request(ctx, http.MethodPost, "/manage/api/v1/holidays", holiday, &mlResponse{Data: &result})
+	return result, err
+}
+
+func (c *mlClient) Holiday(ctx context.Context, id string) (mlHoliday, error) {
+	var result mlHoliday
+	err := c.request(ctx, http.MethodGet, "/manage/api/v1/holidays/"+id, nil, &mlResponse{Data: &result})
+	return result, err
+}
+
+func (c *mlClient) UpdateHoliday(ctx context.Context, holiday mlHoliday) (mlHoliday, error) {
+	id := holiday.ID
+	holiday.ID = ""
+	var result mlHoliday
+	err := c.request(ctx, http.MethodPost, "/manage/api/v1/holidays/"+id, holiday, &mlResponse{Data: &result})
+	return result, err
+}
+
+func (c *mlClient) DeleteHoliday(ctx context.Context, id string) error {
+	return c.request(ctx, http.MethodDelete, "/manage/api/v1/holidays/"+id, nil, nil)
+}
+
+func (c *mlClient) NewOutlierDetector(ctx context.Context, outlier mlOutlierDetector) (mlOutlierDetector, error) {
+	var result mlOutlierDetector
+	err := c.request(ctx, http.MethodPost, "/manage/api/v1/outliers", outlier, &mlResponse{Data: &result})
+	return result, err
+}
+
+func (c *mlClient) OutlierDetector(ctx context.Context, id string) (mlOutlierDetector, error) {
+	var result mlOutlierDetector
+	err := c.request(ctx, http.MethodGet, "/manage/api/v1/outliers/"+id, nil, &mlResponse{Data: &result})
+	return result, err
+}
+
+func (c *mlClient) UpdateOutlierDetector(ctx context.Context, outlier mlOutlierDetector) (mlOutlierDetector, error) {
+	id := outlier.ID
+	outlier.ID = ""
+	var result mlOutlierDetector
+	err := c.request(ctx, http.MethodPost, "/manage/api/v1/outliers/"+id, outlier, &mlResponse{Data: &result})
+	return result, err
+}
+
+func (c *mlClient) DeleteOutlierDetector(ctx context.Context, id string) error {
+	return c.request(ctx, http.MethodDelete, "/manage/api/v1/outliers/"+id, nil, nil)
+}
@@ -0,0 +1,56 @@
+package grafana
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	gapi "github.com/grafana/grafana-api-golang-client"
+)
+
+// The Grafana API client only addresses playlists by numeric ID, which was replaced by UIDs in Grafana 9.
+// The type below extends it, and the playlists are sent to the Grafana API directly.
+// Grafana versions without UIDs accept the numeric ID wherever a UID is expected.
+
+// playlist is a gapi.Playlist with its UID.
+type playlist struct {
+	gapi.Playlist
+	UID string `json:"uid,omitempty"`
+}
+
+// Types of the playlist items. `dashboard_by_id` is deprecated in favor of `dashboard_by_uid`.
+var playlistItemTypes = []string{"dashboard_by_uid", "dashboard_by_tag", "dashboard_by_id"}
+
+// identifier returns the UID of the playlist, or its numeric ID if the Grafana version doesn't support UIDs.
+func (p playlist) identifier() string {
+	if p.UID != "" {
+		return p.UID
+	}
+	return strconv.Itoa(p.ID)
+}
+
+func (c *client) newPlaylist(ctx context.Context, p playlist) (playlist, error) {
+	var result playlist
+	err := c.grafanaAPIRequest(ctx, http.MethodPost, "/api/playlists", p, &result)
+	return result, err
+}
+
+func (c *client) getPlaylist(ctx context.Context, uid string) (playlist, error) {
+	var result playlist
+	err := c.grafanaAPIRequest(ctx, http.MethodGet, "/api/playlists/"+uid, nil, &result)
+	return result, err
+}
+
+func (c *client) listPlaylists(ctx context.Context) ([]playlist, error) {
+	var result []playlist
+	err := c.grafanaAPIRequest(ctx, http.MethodGet, "/api/playlists", nil, &result)
+	return result, err
+}
+
+func (c *client) updatePlaylist(ctx context.Context, uid string, p playlist) error {
+	return c.grafanaAPIRequest(ctx, http.MethodPut, "/api/playlists/"+uid, p, nil)
+}
+
+func (c *client) deletePlaylist(ctx context.Context, uid string) error {
+	return c.grafanaAPIRequest(ctx, http.MethodDelete, "/api/playlists/"+uid, nil, nil)
+}
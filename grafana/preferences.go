@@ -0,0 +1,141 @@
+package grafana
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+
+	gapi "github.com/grafana/grafana-api-golang-client"
+)
+
+// The user, organization and team preferences share the same model and are managed by the same functions.
+// The Grafana API client only supports some fields of the team preferences, so the preferences are sent to the Grafana API directly.
+
+// preferences is a gapi.Preferences with the fields that the Grafana API client doesn't support yet.
+type preferences struct {
+	gapi.Preferences
+	HomeDashboardUID string                   `json:"homeDashboardUID,omitempty"`
+	WeekStart        string                   `json:"weekStart"`
+	Locale           string                   `json:"locale"`
+	QueryHistory     *preferencesQueryHistory `json:"queryHistory,omitempty"`
+}
+
+type preferencesQueryHistory struct {
+	HomeTab string `json:"homeTab"`
+}
+
+func (c *client) getPreferences(ctx context.Context, requestPath string) (preferences, error) {
+	var result preferences
+	err := c.grafanaAPIRequest(ctx, http.MethodGet, requestPath, nil, &result)
+	return result, err
+}
+
+func (c *client) updatePreferences(ctx context.Context, requestPath string, p preferences) error {
+	return c.grafanaAPIRequest(ctx, http.MethodPut, requestPath, p, nil)
+}
+
+// preferencesSchema returns the attributes of the preferences, which are added to the schemas of all preferences resources.
+// scope and member describe, in the attribute descriptions, what the preferences apply to and the users concerned.
+func preferencesSchema(scope, member string) map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"theme": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringInSlice([]string{"light", "dark", "system", ""}, false),
+			Description:  fmt.Sprintf("The theme for the %s. Available themes are `light`, `dark`, `system`, or an empty string for the default theme.", scope),
+		},
+		"home_dashboard_id": {
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Description: fmt.Sprintf("The numeric ID of the dashboard to display when a %s logs in. Either this or `home_dashboard_uid` can be set.", member),
+			DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+				// The ID is returned by the API when the home dashboard is set by UID
+				return new == "0" && d.Get("home_dashboard_uid").(string) != ""
+			},
+		},
+		"home_dashboard_uid": {
+			Type:          schema.TypeString,
+			Optional:      true,
+			Description:   fmt.Sprintf("The UID of the dashboard to display when a %s logs in. Either this or `home_dashboard_id` can be set.", member),
+			ConflictsWith: []string{"home_dashboard_id"},
+			DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+				// The UID is returned by the API when the home dashboard is set by ID
+				return new == "" && d.Get("home_dashboard_id").(int) != 0
+			},
+		},
+		"timezone": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validatePreferencesTimezone,
+			Description:  fmt.Sprintf("The timezone for the %s. Available values are `utc`, `browser`, an IANA time zone such as `Europe/Berlin`, or an empty string for the default.", scope),
+		},
+		"week_start": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringInSlice([]string{"sunday", "monday", "saturday", ""}, false),
+			Description:  fmt.Sprintf("The day on which the week starts for the %s. Available values are `sunday`, `monday`, `saturday`, or an empty string for the default.", scope),
+		},
+		"locale": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: fmt.Sprintf("The locale for the %s, such as `en-US`, or an empty string for the default.", scope),
+		},
+		"query_history_home_tab": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringInSlice([]string{"query", "starred", ""}, false),
+			Description:  fmt.Sprintf("The tab of the query history opened by default for the %s. Available values are `query`, `starred`, or an empty string for the default.", scope),
+		},
+	}
+}
+
+func validatePreferencesTimezone(i interface{}, k string) ([]string, []error) {
+	switch v := i.(string); v {
+	case "utc", "browser", "":
+		return nil, nil
+	default:
+		if _, err := time.LoadLocation(v); err != nil {
+			return nil, []error{fmt.Errorf("%s must be `utc`, `browser`, an IANA time zone or empty: %w", k, err)}
+		}
+	}
+	return nil, nil
+}
+
+func expandPreferences(d *schema.ResourceData) preferences {
+	p := preferences{
+		Preferences: gapi.Preferences{
+			Theme:    d.Get("theme").(string),
+			Timezone: d.Get("timezone").(string),
+		},
+		WeekStart: d.Get("week_start").(string),
+		Locale:    d.Get("locale").(string),
+	}
+	// Only send the home dashboard reference that is configured, the other one is set by the API
+	if config := d.GetRawConfig(); !config.IsNull() && !config.GetAttr("home_dashboard_uid").IsNull() {
+		p.HomeDashboardUID = d.Get("home_dashboard_uid").(string)
+	} else {
+		p.HomeDashboardID = int64(d.Get("home_dashboard_id").(int))
+	}
+	if homeTab := d.Get("query_history_home_tab").(string); homeTab != "" {
+		p.QueryHistory = &preferencesQueryHistory{HomeTab: homeTab}
+	}
+	return p
+}
+
+func flattenPreferences(d *schema.ResourceData, p preferences) {
+	d.Set("theme", p.Theme)
+	d.Set("home_dashboard_id", p.HomeDashboardID)
+	d.Set("home_dashboard_uid", p.HomeDashboardUID)
+	d.Set("timezone", p.Timezone)
+	d.Set("week_start", p.WeekStart)
+	d.Set("locale", p.Locale)
+	homeTab := ""
+	if p.QueryHistory != nil {
+		homeTab = p.QueryHistory.HomeTab
+	}
+	d.Set("query_history_home_tab", homeTab)
+}
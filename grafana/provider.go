@@ -6,10 +6,14 @@ import (
 	"crypto/x509"
 	"encoding/json"
 	"fmt"
+	"log"
+	"net/http"
 	"net/url"
 	"os"
 	"regexp"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/hashicorp/go-cleanhttp"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -23,11 +27,10 @@ import (
 )
 
 var (
-	idRegexp             = regexp.MustCompile(`^\d+$`)
-	uidRegexp            = regexp.MustCompile(`^[a-zA-Z0-9-_]+$`)
-	emailRegexp          = regexp.MustCompile(`.+\@.+\..+`)
-	sha256Regexp         = regexp.MustCompile(`^[A-Fa-f0-9]{64}$`)
-	storeDashboardSHA256 bool
+	idRegexp     = regexp.MustCompile(`^\d+$`)
+	uidRegexp    = regexp.MustCompile(`^[a-zA-Z0-9-_]+$`)
+	emailRegexp  = regexp.MustCompile(`.+\@.+\..+`)
+	sha256Regexp = regexp.MustCompile(`^[A-Fa-f0-9]{64}$`)
 )
 
 func init() {
@@ -61,6 +64,16 @@ func Provider(version string) func() *schema.Provider {
 					Description:  "API token or basic auth username:password. May alternatively be set via the `GRAFANA_AUTH` environment variable.",
 					AtLeastOneOf: []string{"auth", "cloud_api_key", "sm_access_token"},
 				},
+				"cloud_stack_slug": {
+					Type:          schema.TypeString,
+					Optional:      true,
+					DefaultFunc:   schema.EnvDefaultFunc("GRAFANA_CLOUD_STACK_SLUG", nil),
+					ConflictsWith: []string{"url", "auth"},
+					Description: "Slug of a Grafana Cloud stack to manage Grafana resources in, instead of `url` and `auth`. " +
+						"The stack's URL is looked up and a short-lived Admin API key is created through the Grafana Cloud API when the first Grafana request is made, " +
+						"so the stack may be created in the same apply. A key is created for each provider instance and expires after two hours; " +
+						"the expired keys are deleted from the stack when a new one is created. Requires `cloud_api_key`. May alternatively be set via the `GRAFANA_CLOUD_STACK_SLUG` environment variable.",
+				},
 				"http_headers": {
 					Type:        schema.TypeMap,
 					Optional:    true,
@@ -138,30 +151,33 @@ func Provider(version string) func() *schema.Provider {
 					Type:        schema.TypeBool,
 					Optional:    true,
 					DefaultFunc: schema.EnvDefaultFunc("GRAFANA_STORE_DASHBOARD_SHA256", false),
-					Description: "Set to true if you want to save only the sha256sum instead of complete dashboard model JSON in the tfstate.",
+					Description: "Set to true if you want to save only the sha256sum instead of complete dashboard model JSON in the tfstate. This is the default of the `config_json_storage` attribute of `grafana_dashboard`, which can be set per dashboard.",
 				},
 			},
 
 			ResourcesMap: map[string]*schema.Resource{
 				// Grafana
-				"grafana_api_key":                 ResourceAPIKey(),
-				"grafana_alert_notification":      ResourceAlertNotification(),
-				"grafana_builtin_role_assignment": ResourceBuiltInRoleAssignment(),
-				"grafana_dashboard":               ResourceDashboard(),
-				"grafana_dashboard_permission":    ResourceDashboardPermission(),
-				"grafana_data_source":             ResourceDataSource(),
-				"grafana_data_source_permission":  ResourceDatasourcePermission(),
-				"grafana_folder":                  ResourceFolder(),
-				"grafana_folder_permission":       ResourceFolderPermission(),
-				"grafana_library_panel":           ResourceLibraryPanel(),
-				"grafana_organization":            ResourceOrganization(),
-				"grafana_playlist":                ResourcePlaylist(),
-				"grafana_report":                  ResourceReport(),
-				"grafana_role":                    ResourceRole(),
-				"grafana_team":                    ResourceTeam(),
-				"grafana_team_preferences":        ResourceTeamPreferences(),
-				"grafana_team_external_group":     ResourceTeamExternalGroup(),
-				"grafana_user":                    ResourceUser(),
+				"grafana_api_key":                  ResourceAPIKey(),
+				"grafana_alert_notification":       ResourceAlertNotification(),
+				"grafana_builtin_role_assignment":  ResourceBuiltInRoleAssignment(),
+				"grafana_dashboard":                ResourceDashboard(),
+				"grafana_dashboard_permission":     ResourceDashboardPermission(),
+				"grafana_data_source":              ResourceDataSource(),
+				"grafana_data_source_permission":   ResourceDatasourcePermission(),
+				"grafana_folder":                   ResourceFolder(),
+				"grafana_folder_permission":        ResourceFolderPermission(),
+				"grafana_library_panel":            ResourceLibraryPanel(),
+				"grafana_organization":             ResourceOrganization(),
+				"grafana_organization_preferences": ResourceOrganizationPreferences(),
+				"grafana_playlist":                 ResourcePlaylist(),
+				"grafana_report":                   ResourceReport(),
+				"grafana_report_settings":          ResourceReportSettings(),
+				"grafana_role":                     ResourceRole(),
+				"grafana_team":                     ResourceTeam(),
+				"grafana_team_preferences":         ResourceTeamPreferences(),
+				"grafana_team_external_group":      ResourceTeamExternalGroup(),
+				"grafana_user":                     ResourceUser(),
+				"grafana_user_preferences":         ResourceUserPreferences(),
 
 				// Cloud
 				"grafana_cloud_api_key": ResourceCloudAPIKey(),
@@ -169,27 +185,36 @@ func Provider(version string) func() *schema.Provider {
 
 				// Synthetic Monitoring
 				"grafana_synthetic_monitoring_check":        ResourceSyntheticMonitoringCheck(),
+				"grafana_synthetic_monitoring_check_alerts": ResourceSyntheticMonitoringCheckAlerts(),
 				"grafana_synthetic_monitoring_probe":        ResourceSyntheticMonitoringProbe(),
 				"grafana_synthetic_monitoring_installation": ResourceSyntheticMonitoringInstallation(),
+				"grafana_synthetic_monitoring_tenant":       ResourceSyntheticMonitoringTenant(),
 
 				// Machine Learning
-				"grafana_machine_learning_job": ResourceMachineLearningJob(),
+				"grafana_machine_learning_job":              ResourceMachineLearningJob(),
+				"grafana_machine_learning_holiday":          ResourceMachineLearningHoliday(),
+				"grafana_machine_learning_outlier_detector": ResourceMachineLearningOutlierDetector(),
 			},
 
 			DataSourcesMap: map[string]*schema.Resource{
 				// Grafana
-				"grafana_dashboard":     DatasourceDashboard(),
-				"grafana_dashboards":    DatasourceDashboards(),
-				"grafana_folder":        DatasourceFolder(),
-				"grafana_library_panel": DatasourceLibraryPanel(),
-				"grafana_user":          DatasourceUser(),
+				"grafana_dashboard":      DatasourceDashboard(),
+				"grafana_dashboards":     DatasourceDashboards(),
+				"grafana_folder":         DatasourceFolder(),
+				"grafana_jsonnet":        DatasourceJsonnet(),
+				"grafana_library_panel":  DatasourceLibraryPanel(),
+				"grafana_library_panels": DatasourceLibraryPanels(),
+				"grafana_user":           DatasourceUser(),
 
 				// Cloud
-				"grafana_cloud_stack": DatasourceCloudStack(),
+				"grafana_cloud_regions": DatasourceCloudRegions(),
+				"grafana_cloud_stack":   DatasourceCloudStack(),
 
 				// Synthetic Monitoring
+				"grafana_synthetic_monitoring_checks": DatasourceSyntheticMonitoringChecks(),
 				"grafana_synthetic_monitoring_probe":  DatasourceSyntheticMonitoringProbe(),
 				"grafana_synthetic_monitoring_probes": DatasourceSyntheticMonitoringProbes(),
+				"grafana_synthetic_monitoring_tenant": DatasourceSyntheticMonitoringTenant(),
 			},
 		}
 
@@ -205,10 +230,42 @@ type client struct {
 	gapiConfig *gapi.Config
 	gcloudapi  *gapi.Client
 
-	smapi *smapi.Client
-	smURL string
+	// gcloudHTTPClient is the HTTP client used for Grafana Cloud requests, including stack readiness checks
+	gcloudHTTPClient *http.Client
+	gcloudAPIURL     string
+	gcloudAPIKey     string
+
+	// cloudRegions caches the Grafana Cloud regions for the lifetime of the provider instance
+	cloudRegionsMu sync.Mutex
+	cloudRegions   []gapi.CloudRegion
+
+	smapi        *smapi.Client
+	smURL        string
+	smToken      string
+	smHTTPClient *http.Client
 
-	mlapi *mlapi.Client
+	mlapi *mlClient
+
+	// storeDashboardSHA256 is the default storage mode of the dashboards' config_json, set per provider instance
+	storeDashboardSHA256 bool
+}
+
+// getCloudRegions returns the Grafana Cloud regions, fetching them from the Cloud API on first use
+func (c *client) getCloudRegions() ([]gapi.CloudRegion, error) {
+	c.cloudRegionsMu.Lock()
+	defer c.cloudRegionsMu.Unlock()
+
+	if c.cloudRegions != nil {
+		return c.cloudRegions, nil
+	}
+
+	resp, err := c.gcloudapi.GetCloudRegions()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Grafana Cloud regions: %w", err)
+	}
+	c.cloudRegions = resp.Items
+
+	return c.cloudRegions, nil
 }
 
 func configure(version string, p *schema.Provider) func(context.Context, *schema.ResourceData) (interface{}, diag.Diagnostics) {
@@ -221,27 +278,32 @@ func configure(version string, p *schema.Provider) func(context.Context, *schema
 
 		c := &client{}
 
-		c.gapiURL, c.gapiConfig, c.gapi, err = createGrafanaClient(d)
+		c.gcloudHTTPClient, c.gcloudapi, err = createCloudClient(d)
 		if err != nil {
 			return nil, diag.FromErr(err)
 		}
-		c.gcloudapi, err = createCloudClient(d)
-		if err != nil {
-			return nil, diag.FromErr(err)
+		c.gcloudAPIURL = d.Get("cloud_api_url").(string)
+		c.gcloudAPIKey = d.Get("cloud_api_key").(string)
+		var grafanaDiags diag.Diagnostics
+		c.gapiURL, c.gapiConfig, c.gapi, grafanaDiags = createGrafanaClient(d, c.gcloudapi)
+		diags = append(diags, grafanaDiags...)
+		if diags.HasError() {
+			return nil, diags
 		}
 		c.mlapi, err = createMLClient(c.gapiURL, c.gapiConfig)
 		if err != nil {
 			return nil, diag.FromErr(err)
 		}
-		c.smURL, c.smapi = createSMClient(d)
+		c.smURL, c.smToken, c.smHTTPClient, c.smapi = createSMClient(d)
 
-		storeDashboardSHA256 = d.Get("store_dashboard_sha256").(bool)
+		c.storeDashboardSHA256 = d.Get("store_dashboard_sha256").(bool)
 
 		return c, diags
 	}
 }
 
-func createGrafanaClient(d *schema.ResourceData) (string, *gapi.Config, *gapi.Client, error) {
+func createGrafanaClient(d *schema.ResourceData, cloudClient *gapi.Client) (string, *gapi.Config, *gapi.Client, diag.Diagnostics) {
+	var diags diag.Diagnostics
 	auth := strings.SplitN(d.Get("auth").(string), ":", 2)
 	cli := cleanhttp.DefaultClient()
 	transport := cleanhttp.DefaultTransport()
@@ -255,7 +317,7 @@ func createGrafanaClient(d *schema.ResourceData) (string, *gapi.Config, *gapi.Cl
 	if caCert != "" {
 		ca, err := os.ReadFile(caCert)
 		if err != nil {
-			return "", nil, nil, err
+			return "", nil, nil, diag.FromErr(err)
 		}
 		pool := x509.NewCertPool()
 		pool.AppendCertsFromPEM(ca)
@@ -264,7 +326,7 @@ func createGrafanaClient(d *schema.ResourceData) (string, *gapi.Config, *gapi.Cl
 	if tlsKey != "" && tlsCert != "" {
 		cert, err := tls.LoadX509KeyPair(tlsCert, tlsKey)
 		if err != nil {
-			return "", nil, nil, err
+			return "", nil, nil, diag.FromErr(err)
 		}
 		transport.TLSClientConfig.Certificates = []tls.Certificate{cert}
 	}
@@ -274,6 +336,35 @@ func createGrafanaClient(d *schema.ResourceData) (string, *gapi.Config, *gapi.Cl
 
 	apiURL := d.Get("url").(string)
 	cli.Transport = logging.NewTransport("Grafana", transport)
+	if stackSlug := d.Get("cloud_stack_slug").(string); stackSlug != "" {
+		if d.Get("cloud_api_key").(string) == "" {
+			return "", nil, nil, diag.Errorf("cloud_api_key must be set when using cloud_stack_slug")
+		}
+		stackTransport := &cloudStackTransport{
+			base:        cli.Transport,
+			cloudClient: cloudClient,
+			stackSlug:   stackSlug,
+		}
+		cli.Transport = stackTransport
+		apiURL = stackTransport.defaultStackURL()
+
+		// The stack may not exist yet if it's created in the same apply. In that case, it is resolved on the first request
+		stack, err := cloudClient.StackBySlug(stackSlug)
+		switch {
+		case err == nil:
+			if stack.URL != "" {
+				apiURL = stack.URL
+			}
+		case strings.HasPrefix(err.Error(), "status: 404"):
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("Cloud stack %s was not found", stackSlug),
+				Detail:   "The stack is looked up again on the first Grafana request, in case it is created in this run.",
+			})
+		default:
+			return "", nil, nil, diag.Errorf("failed to get cloud stack %s: %s", stackSlug, err)
+		}
+	}
 	cfg := gapi.Config{
 		Client:     cli,
 		NumRetries: d.Get("retries").(int),
@@ -291,7 +382,7 @@ func createGrafanaClient(d *schema.ResourceData) (string, *gapi.Config, *gapi.Cl
 		var err error
 		headersMap, err = getJSONMap("GRAFANA_HTTP_HEADERS")
 		if err != nil {
-			return "", nil, nil, fmt.Errorf("invalid http_headers config: %w", err)
+			return "", nil, nil, diag.Errorf("invalid http_headers config: %s", err)
 		}
 	}
 	if len(headersMap) > 0 {
@@ -306,12 +397,109 @@ func createGrafanaClient(d *schema.ResourceData) (string, *gapi.Config, *gapi.Cl
 
 	gclient, err := gapi.New(apiURL, cfg)
 	if err != nil {
-		return "", nil, nil, err
+		return "", nil, nil, diag.FromErr(err)
 	}
-	return apiURL, &cfg, gclient, nil
+	return apiURL, &cfg, gclient, diags
+}
+
+// cloudStackTemporaryKeyTTL is the lifetime of the API keys created for `cloud_stack_slug`.
+// A key is created for each provider instance, so this must cover the duration of a Terraform run.
+const cloudStackTemporaryKeyTTL = 2 * time.Hour
+
+// cloudStackTemporaryKeyPrefix is the name prefix of the API keys created for `cloud_stack_slug`.
+// The expired keys with this prefix are deleted when a new key is created, so that they don't pile up in the stack.
+const cloudStackTemporaryKeyPrefix = "terraform-provider-temporary-"
+
+// cloudStackTransport sends Grafana API requests to a Grafana Cloud stack.
+// The stack's URL and an API key are only resolved on the first request, so that the provider can be configured
+// for a stack that is created within the same Terraform run. If resolving fails, it is retried on the next request.
+type cloudStackTransport struct {
+	base        http.RoundTripper
+	cloudClient *gapi.Client
+	stackSlug   string
+
+	mu       sync.Mutex
+	stackURL *url.URL
+	apiKey   string
+}
+
+func (t *cloudStackTransport) defaultStackURL() string {
+	return fmt.Sprintf("https://%s.grafana.net", t.stackSlug)
 }
 
-func createMLClient(url string, grafanaCfg *gapi.Config) (*mlapi.Client, error) {
+// resolve looks up the stack's URL and creates an API key, unless a previous call succeeded.
+func (t *cloudStackTransport) resolve() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.apiKey != "" {
+		return nil
+	}
+
+	stack, err := t.cloudClient.StackBySlug(t.stackSlug)
+	if err != nil {
+		return fmt.Errorf("failed to get cloud stack %s: %w", t.stackSlug, err)
+	}
+	stackURL, err := url.Parse(stack.URL)
+	if err != nil {
+		return fmt.Errorf("invalid URL for cloud stack %s: %w", t.stackSlug, err)
+	}
+
+	key, err := t.cloudClient.CreateGrafanaAPIKeyFromCloud(t.stackSlug, &gapi.CreateAPIKeyRequest{
+		Name:          fmt.Sprintf("%s%d", cloudStackTemporaryKeyPrefix, time.Now().UnixNano()),
+		Role:          "Admin",
+		SecondsToLive: int64(cloudStackTemporaryKeyTTL.Seconds()),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create an API key for cloud stack %s: %w", t.stackSlug, err)
+	}
+	t.stackURL, t.apiKey = stackURL, key.Key
+
+	t.deleteExpiredKeys(stack.URL)
+	return nil
+}
+
+// deleteExpiredKeys deletes the expired keys created for `cloud_stack_slug` by earlier provider instances.
+// Failing to delete them doesn't prevent the use of the stack, so errors are only logged.
+func (t *cloudStackTransport) deleteExpiredKeys(stackURL string) {
+	stackClient, err := gapi.New(stackURL, gapi.Config{APIKey: t.apiKey, Client: &http.Client{Transport: t.base}})
+	if err != nil {
+		log.Printf("[WARN] could not delete the expired API keys of cloud stack %s: %s", t.stackSlug, err)
+		return
+	}
+	keys, err := stackClient.GetAPIKeys(true)
+	if err != nil {
+		log.Printf("[WARN] could not list the API keys of cloud stack %s: %s", t.stackSlug, err)
+		return
+	}
+	for _, key := range keys {
+		if !strings.HasPrefix(key.Name, cloudStackTemporaryKeyPrefix) || key.Expiration.IsZero() || key.Expiration.After(time.Now()) {
+			continue
+		}
+		if _, err := stackClient.DeleteAPIKey(key.ID); err != nil {
+			log.Printf("[WARN] could not delete the expired API key %s of cloud stack %s: %s", key.Name, t.stackSlug, err)
+		}
+	}
+}
+
+func (t *cloudStackTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.resolve(); err != nil {
+		return nil, err
+	}
+
+	req = req.Clone(req.Context())
+	req.URL.Scheme = t.stackURL.Scheme
+	req.URL.Host = t.stackURL.Host
+	if stackPath := strings.TrimRight(t.stackURL.Path, "/"); stackPath != "" {
+		req.URL.Path = stackPath + req.URL.Path
+		req.URL.RawPath = ""
+	}
+	req.Host = ""
+	req.Header.Set("Authorization", "Bearer "+t.apiKey)
+
+	return t.base.RoundTrip(req)
+}
+
+func createMLClient(url string, grafanaCfg *gapi.Config) (*mlClient, error) {
 	mlcfg := mlapi.Config{
 		BasicAuth:   grafanaCfg.BasicAuth,
 		BearerToken: grafanaCfg.APIKey,
@@ -323,24 +511,33 @@ func createMLClient(url string, grafanaCfg *gapi.Config) (*mlapi.Client, error)
 		mlURL += "/"
 	}
 	mlURL += "api/plugins/grafana-ml-app/resources"
-	mlclient, err := mlapi.New(mlURL, mlcfg)
+	mlclient, err := newMLClient(mlURL, mlcfg)
 	if err != nil {
 		return nil, err
 	}
 	return mlclient, nil
 }
 
-func createCloudClient(d *schema.ResourceData) (*gapi.Client, error) {
+func createCloudClient(d *schema.ResourceData) (*http.Client, *gapi.Client, error) {
+	cli := cleanhttp.DefaultClient()
+	cli.Transport = logging.NewTransport("Grafana Cloud", cleanhttp.DefaultTransport())
 	cfg := gapi.Config{
 		APIKey: d.Get("cloud_api_key").(string),
+		Client: cli,
 	}
-	return gapi.New(d.Get("cloud_api_url").(string), cfg)
+	gclient, err := gapi.New(d.Get("cloud_api_url").(string), cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cli, gclient, nil
 }
 
-func createSMClient(d *schema.ResourceData) (string, *smapi.Client) {
+func createSMClient(d *schema.ResourceData) (string, string, *http.Client, *smapi.Client) {
 	smToken := d.Get("sm_access_token").(string)
 	smURL := d.Get("sm_url").(string)
-	return smURL, smapi.NewClient(smURL, smToken, nil)
+	cli := cleanhttp.DefaultClient()
+	cli.Transport = logging.NewTransport("Synthetic Monitoring", cleanhttp.DefaultTransport())
+	return smURL, smToken, cli, smapi.NewClient(smURL, smToken, cli)
 }
 
 // getJSONMap is a helper function that parses the given environment variable as a JSON object
@@ -2,7 +2,10 @@ package grafana
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"reflect"
 	"regexp"
 	"strconv"
 	"strings"
@@ -10,6 +13,7 @@ import (
 	"testing"
 
 	"github.com/Masterminds/semver/v3"
+	gapi "github.com/grafana/grafana-api-golang-client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -305,3 +309,88 @@ func CheckEnterpriseTestsEnabled(t *testing.T) {
 		"GRAFANA_ORG_ID",
 	)
 }
+
+func TestCloudStackTransport(t *testing.T) {
+	IsUnitTest(t)
+
+	var deletedKeys []string
+	stackServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer stack-key" {
+			t.Errorf("expected stack request to use the temporary key, got Authorization %q", got)
+		}
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/folders":
+			w.Write([]byte(`[]`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/auth/keys":
+			if r.URL.Query().Get("includeExpired") != "true" {
+				t.Errorf("expected the expired keys to be listed")
+			}
+			w.Write([]byte(`[
+				{"id": 1, "name": "terraform-provider-temporary-1", "expiration": "2000-01-01T00:00:00Z"},
+				{"id": 2, "name": "terraform-provider-temporary-2", "expiration": "2999-01-01T00:00:00Z"},
+				{"id": 3, "name": "user-key", "expiration": "2000-01-01T00:00:00Z"},
+				{"id": 4, "name": "terraform-provider-temporary-4"}
+			]`))
+		case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/api/auth/keys/"):
+			deletedKeys = append(deletedKeys, strings.TrimPrefix(r.URL.Path, "/api/auth/keys/"))
+			w.Write([]byte(`{"message": "API key deleted"}`))
+		default:
+			t.Errorf("unexpected stack request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer stackServer.Close()
+
+	stackLookups, keysCreated := 0, 0
+	cloudServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/instances/mystack":
+			stackLookups++
+			// The first lookup fails temporarily
+			if stackLookups == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.Write([]byte(`{"id": 1, "slug": "mystack", "url": "` + stackServer.URL + `"}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/api/instances/mystack/api/auth/keys":
+			keysCreated++
+			w.Write([]byte(`{"id": 5, "name": "terraform-provider-temporary-5", "key": "stack-key"}`))
+		default:
+			t.Errorf("unexpected cloud request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer cloudServer.Close()
+
+	cloudClient, err := gapi.New(cloudServer.URL, gapi.Config{APIKey: "cloud-key"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	transport := &cloudStackTransport{
+		base:        http.DefaultTransport,
+		cloudClient: cloudClient,
+		stackSlug:   "mystack",
+	}
+	stackClient, err := gapi.New(transport.defaultStackURL(), gapi.Config{Client: &http.Client{Transport: transport}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := stackClient.Folders(); err == nil || !strings.Contains(err.Error(), "failed to get cloud stack mystack") {
+		t.Fatalf("expected the first request to fail to get the stack, got %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := stackClient.Folders(); err != nil {
+			t.Fatalf("failed to list folders: %v", err)
+		}
+	}
+	if stackLookups != 2 {
+		t.Errorf("expected the stack to be looked up again after the failure only, got %d lookups", stackLookups)
+	}
+	if keysCreated != 1 {
+		t.Errorf("expected a single API key to be created, got %d", keysCreated)
+	}
+	if !reflect.DeepEqual(deletedKeys, []string{"1"}) {
+		t.Errorf("expected only the expired temporary key to be deleted, got %v", deletedKeys)
+	}
+}
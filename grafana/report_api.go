@@ -0,0 +1,87 @@
+package grafana
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	gapi "github.com/grafana/grafana-api-golang-client"
+)
+
+// The Grafana API client doesn't support reports with multiple dashboards, template variables or output formats.
+// The types below extend it, and the reports are sent to the Grafana API directly.
+
+// report is a gapi.Report that can contain multiple dashboards and output formats.
+type report struct {
+	gapi.Report
+	Schedule   reportSchedule    `json:"schedule"`
+	Dashboards []reportDashboard `json:"dashboards,omitempty"`
+	Formats    []string          `json:"formats,omitempty"`
+}
+
+// reportSchedule is a gapi.ReportSchedule that can be sent on the last day of the month.
+type reportSchedule struct {
+	gapi.ReportSchedule
+	DayOfMonth string `json:"dayOfMonth,omitempty"`
+}
+
+type reportDashboard struct {
+	Dashboard       reportDashboardRef   `json:"dashboard"`
+	TimeRange       gapi.ReportTimeRange `json:"timeRange"`
+	ReportVariables map[string][]string  `json:"reportVariables,omitempty"`
+}
+
+type reportDashboardRef struct {
+	ID  int64  `json:"id,omitempty"`
+	UID string `json:"uid"`
+}
+
+// Output formats supported by reports.
+var reportFormats = []string{"pdf", "csv", "image"}
+
+func (c *client) newReport(ctx context.Context, r report) (int64, error) {
+	var result struct {
+		ID int64 `json:"id"`
+	}
+	if err := c.grafanaAPIRequest(ctx, http.MethodPost, "/api/reports", r, &result); err != nil {
+		return 0, err
+	}
+	return result.ID, nil
+}
+
+func (c *client) getReport(ctx context.Context, id int64) (report, error) {
+	var result report
+	err := c.grafanaAPIRequest(ctx, http.MethodGet, fmt.Sprintf("/api/reports/%d", id), nil, &result)
+	return result, err
+}
+
+func (c *client) updateReport(ctx context.Context, r report) error {
+	return c.grafanaAPIRequest(ctx, http.MethodPut, fmt.Sprintf("/api/reports/%d", r.ID), r, nil)
+}
+
+// reportSettings are the org-wide settings of the reports. They aren't supported by the Grafana API client yet.
+type reportSettings struct {
+	ID                 int64                  `json:"id,omitempty"`
+	OrgID              int64                  `json:"orgId,omitempty"`
+	Branding           reportSettingsBranding `json:"branding"`
+	PDFTheme           string                 `json:"pdfTheme,omitempty"`
+	EmbeddedImageTheme string                 `json:"embeddedImageTheme,omitempty"`
+}
+
+type reportSettingsBranding struct {
+	ReportLogoURL   string `json:"reportLogoUrl"`
+	EmailLogoURL    string `json:"emailLogoUrl"`
+	EmailFooterMode string `json:"emailFooterMode"`
+	EmailFooterText string `json:"emailFooterText"`
+	EmailFooterLink string `json:"emailFooterLink"`
+}
+
+func (c *client) getReportSettings(ctx context.Context) (reportSettings, error) {
+	var result reportSettings
+	err := c.grafanaAPIRequest(ctx, http.MethodGet, "/api/reports/settings", nil, &result)
+	return result, err
+}
+
+func (c *client) saveReportSettings(ctx context.Context, settings reportSettings) error {
+	return c.grafanaAPIRequest(ctx, http.MethodPost, "/api/reports/settings", settings, nil)
+}
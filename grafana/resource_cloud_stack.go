@@ -19,7 +19,12 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
-var stackSlugRegex = regexp.MustCompile("^[a-z][a-z0-9]+$")
+var (
+	stackSlugRegex = regexp.MustCompile("^[a-z][a-z0-9]+$")
+
+	// stackComponents are the stack components that can be waited on, see `wait_for_components`
+	stackComponents = []string{"prometheus", "logs", "alertmanager"}
+)
 
 func ResourceCloudStack() *schema.Resource {
 	return &schema.Resource{
@@ -33,8 +38,15 @@ func ResourceCloudStack() *schema.Resource {
 		DeleteContext: DeleteStack,
 		ReadContext:   ReadStack,
 
+		CustomizeDiff: customizeDiffStackRegion,
+
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStateWithDeleteProtection,
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -66,8 +78,8 @@ available at “https://<stack_slug>.grafana.net".`,
 				Optional: true,
 				ForceNew: true,
 				Description: `Region slug to assign to this stack.
-Changing region will destroy the existing stack and create a new one in the desired region`,
-				ValidateFunc: validation.StringInSlice([]string{"au", "eu", "us"}, false),
+Changing region will destroy the existing stack and create a new one in the desired region.
+Available regions can be listed with the ` + "`grafana_cloud_regions`" + ` data source.`,
 			},
 			"url": {
 				Type:        schema.TypeString,
@@ -79,7 +91,18 @@ Changing region will destroy the existing stack and create a new one in the desi
 				Type:        schema.TypeBool,
 				Optional:    true,
 				Default:     true,
-				Description: "Whether to wait for readiness of the stack after creating it. The check is a HEAD request to the stack URL (Grafana instance).",
+				Description: "Whether to wait for readiness of the stack after creating it. The check is a HEAD request to the stack URL (Grafana instance). The wait is bounded by the resource's `create` and `update` timeouts.",
+			},
+			"delete_protection": deleteProtectionSchema("stack"),
+			"wait_for_components": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Description: "Components of the stack whose status must be `active` before the stack is considered ready. " +
+					"Only used if `wait_for_readiness` is true. Possible values: " + strings.Join(stackComponents, ", ") + ".",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(stackComponents, false),
+				},
 			},
 			"org_id": {
 				Type:        schema.TypeInt,
@@ -167,6 +190,66 @@ Changing region will destroy the existing stack and create a new one in the desi
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"graphite_user_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "User ID of the Graphite instance configured for this stack.",
+			},
+			"graphite_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of the Graphite instance configured for this stack.",
+			},
+			"graphite_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Base URL of the Graphite instance configured for this stack.",
+			},
+			"graphite_status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Status of the Graphite instance configured for this stack.",
+			},
+			"traces_user_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "User ID of the Tempo (traces) instance configured for this stack.",
+			},
+			"traces_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of the Tempo (traces) instance configured for this stack.",
+			},
+			"traces_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Base URL of the Tempo (traces) instance configured for this stack.",
+			},
+			"traces_status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Status of the Tempo (traces) instance configured for this stack.",
+			},
+			"profiles_user_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "User ID of the profiles instance configured for this stack.",
+			},
+			"profiles_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of the profiles instance configured for this stack.",
+			},
+			"profiles_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Base URL of the profiles instance configured for this stack.",
+			},
+			"profiles_status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Status of the profiles instance configured for this stack.",
+			},
 		},
 	}
 }
@@ -196,7 +279,7 @@ func CreateStack(ctx context.Context, d *schema.ResourceData, meta interface{})
 		return diag
 	}
 
-	return waitForStackReadiness(ctx, d)
+	return waitForStackReadiness(ctx, d, meta, d.Timeout(schema.TimeoutCreate))
 }
 
 func UpdateStack(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -204,7 +287,8 @@ func UpdateStack(ctx context.Context, d *schema.ResourceData, meta interface{})
 	stackID, _ := strconv.ParseInt(d.Id(), 10, 64)
 
 	// The underlying API olnly allows to update the name and description.
-	allowedChanges := []string{"name", "description", "slug"}
+	// The readiness and deletion settings are only used by the provider.
+	allowedChanges := []string{"name", "description", "slug", "wait_for_readiness", "wait_for_components", "delete_protection"}
 	if d.HasChangesExcept(allowedChanges...) {
 		return diag.Errorf("Error: Only name, slug and description can be updated.")
 	}
@@ -225,12 +309,15 @@ func UpdateStack(ctx context.Context, d *schema.ResourceData, meta interface{})
 		return diag
 	}
 
-	return waitForStackReadiness(ctx, d)
+	return waitForStackReadiness(ctx, d, meta, d.Timeout(schema.TimeoutUpdate))
 }
 
 func DeleteStack(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*client).gcloudapi
 	slug := d.Get("slug").(string)
+	if diag := checkDeleteProtection(d, "stack", slug); diag != nil {
+		return diag
+	}
 	if err := client.DeleteStack(slug); err != nil {
 		return diag.FromErr(err)
 	}
@@ -239,15 +326,12 @@ func DeleteStack(ctx context.Context, d *schema.ResourceData, meta interface{})
 }
 
 func ReadStack(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*client).gcloudapi
-
 	idStr := d.Id()
-	id, err := strconv.ParseInt(idStr, 10, 64)
-	if err != nil {
+	if _, err := strconv.ParseInt(idStr, 10, 64); err != nil {
 		return diag.Errorf("Invalid id: %#v", idStr)
 	}
 
-	stack, err := client.StackByID(id)
+	stack, err := meta.(*client).getCloudStack(ctx, idStr)
 	if err != nil {
 		if strings.HasPrefix(err.Error(), "status: 404") {
 			log.Printf("[WARN] removing stack %s from state because it no longer exists in grafana", d.Get("name").(string))
@@ -268,7 +352,23 @@ func ReadStack(ctx context.Context, d *schema.ResourceData, meta interface{}) di
 	return nil
 }
 
-func FlattenStack(d *schema.ResourceData, stack gapi.Stack) {
+// cloudStack is a Grafana Cloud stack, including the fields that are not exposed by gapi.Stack yet
+type cloudStack struct {
+	gapi.Stack
+	HpInstanceID     int    `json:"hpInstanceId"`
+	HpInstanceURL    string `json:"hpInstanceUrl"`
+	HpInstanceName   string `json:"hpInstanceName"`
+	HpInstanceStatus string `json:"hpInstanceStatus"`
+}
+
+// getCloudStack fetches a stack by its ID or slug. Like gapi's StackByID, this returns deleted stacks with `status=deleted`.
+func (c *client) getCloudStack(ctx context.Context, idOrSlug string) (cloudStack, error) {
+	var stack cloudStack
+	err := c.cloudAPIRequest(ctx, http.MethodGet, "/api/instances/"+idOrSlug, nil, &stack)
+	return stack, err
+}
+
+func FlattenStack(d *schema.ResourceData, stack cloudStack) {
 	id := strconv.FormatInt(stack.ID, 10)
 
 	d.SetId(id)
@@ -299,28 +399,98 @@ func FlattenStack(d *schema.ResourceData, stack gapi.Stack) {
 	d.Set("alertmanager_name", stack.AmInstanceName)
 	d.Set("alertmanager_url", stack.AmInstanceURL)
 	d.Set("alertmanager_status", stack.AmInstanceStatus)
+
+	d.Set("graphite_user_id", stack.HmInstanceGraphiteID)
+	d.Set("graphite_name", stack.HmInstanceGraphiteName)
+	d.Set("graphite_url", stack.HmInstanceGraphiteURL)
+	d.Set("graphite_status", stack.HmInstanceGraphiteStatus)
+
+	d.Set("traces_user_id", stack.HtInstanceID)
+	d.Set("traces_name", stack.HtInstanceName)
+	d.Set("traces_url", stack.HtInstanceURL)
+	d.Set("traces_status", stack.HtInstanceStatus)
+
+	d.Set("profiles_user_id", stack.HpInstanceID)
+	d.Set("profiles_name", stack.HpInstanceName)
+	d.Set("profiles_url", stack.HpInstanceURL)
+	d.Set("profiles_status", stack.HpInstanceStatus)
+}
+
+// customizeDiffStackRegion validates the region slug against the regions known by the Grafana Cloud API
+func customizeDiffStackRegion(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if !diff.NewValueKnown("region_slug") || !diff.HasChange("region_slug") {
+		return nil
+	}
+	slug := diff.Get("region_slug").(string)
+	if slug == "" {
+		return nil
+	}
+
+	regions, err := meta.(*client).getCloudRegions()
+	if err != nil {
+		return err
+	}
+
+	return validateCloudRegionSlug(slug, regions)
+}
+
+func validateCloudRegionSlug(slug string, regions []gapi.CloudRegion) error {
+	slugs := make([]string, 0, len(regions))
+	for _, region := range regions {
+		if region.Slug == slug {
+			return nil
+		}
+		slugs = append(slugs, region.Slug)
+	}
+
+	return fmt.Errorf("invalid region_slug %q, expected one of: %s", slug, strings.Join(slugs, ", "))
 }
 
 // waitForStackReadiness retries until the stack is ready, verified by querying the Grafana URL
-func waitForStackReadiness(ctx context.Context, d *schema.ResourceData) diag.Diagnostics {
+// and, if `wait_for_components` is set, the status of each of the given components
+func waitForStackReadiness(ctx context.Context, d *schema.ResourceData, meta interface{}, timeout time.Duration) diag.Diagnostics {
 	if wait := d.Get("wait_for_readiness").(bool); !wait {
 		return nil
 	}
 
-	err := resource.RetryContext(ctx, 2*time.Minute, func() *resource.RetryError {
+	c := meta.(*client)
+	components := setToStringSlice(d.Get("wait_for_components").(*schema.Set))
+
+	err := resource.RetryContext(ctx, timeout, func() *resource.RetryError {
 		req, err := http.NewRequestWithContext(ctx, http.MethodHead, d.Get("url").(string), nil)
 		if err != nil {
 			return resource.NonRetryableError(err)
 		}
-		resp, err := http.DefaultClient.Do(req)
+		resp, err := c.gcloudHTTPClient.Do(req)
 		if err != nil {
-			return resource.NonRetryableError(err)
+			// The stack's DNS record or load balancer may not be available yet
+			return resource.RetryableError(fmt.Errorf("stack is not reachable yet: %w", err))
 		}
 		defer resp.Body.Close()
 		if resp.StatusCode != 200 {
 			return resource.RetryableError(errors.New("stack is not ready yet"))
 		}
 
+		if len(components) == 0 {
+			return nil
+		}
+
+		stack, err := c.getCloudStack(ctx, d.Id())
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+		statuses := map[string]string{
+			"prometheus":   stack.HmInstancePromStatus,
+			"logs":         stack.HlInstanceStatus,
+			"alertmanager": stack.AmInstanceStatus,
+		}
+		for _, component := range components {
+			if status := statuses[component]; status != "active" {
+				return resource.RetryableError(fmt.Errorf("stack component %s is not ready yet (status: %q)", component, status))
+			}
+		}
+		FlattenStack(d, stack)
+
 		return nil
 	})
 	if err != nil {
@@ -2,6 +2,7 @@ package grafana
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 
 	"strconv"
@@ -64,6 +65,9 @@ func TestResourceCloudStack_Basic(t *testing.T) {
 					resource.TestCheckResourceAttr("grafana_cloud_stack.test", "slug", resourceName),
 					resource.TestCheckResourceAttr("grafana_cloud_stack.test", "description", stackDescription),
 					resource.TestCheckResourceAttr("grafana_cloud_stack.test", "status", "active"),
+					resource.TestCheckResourceAttr("grafana_cloud_stack.test", "prometheus_status", "active"),
+					resource.TestCheckResourceAttr("grafana_cloud_stack.test", "logs_status", "active"),
+					resource.TestCheckResourceAttr("grafana_cloud_stack.test", "alertmanager_status", "active"),
 				),
 			},
 		},
@@ -144,6 +148,8 @@ func testAccStackConfigUpdate(name string, slug string, description string) stri
 		slug  = "%s"
 		region_slug = "eu"
 		description = "%s"
+
+		wait_for_components = ["prometheus", "logs", "alertmanager"]
 	  }
 	`, name, slug, description)
 }
@@ -152,3 +158,24 @@ func testAccStackConfigUpdate(name string, slug string, description string) stri
 func GetRandomStackName(prefix string) string {
 	return prefix + acctest.RandStringFromCharSet(10, acctest.CharSetAlphaNum)
 }
+
+func TestResourceCloudStack_InvalidRegion(t *testing.T) {
+	t.Parallel()
+	CheckCloudAPITestsEnabled(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+				resource "grafana_cloud_stack" "test" {
+					name        = "tfinvalidregion"
+					slug        = "tfinvalidregion"
+					region_slug = "doesnotexist"
+				}`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`invalid region_slug "doesnotexist", expected one of: `),
+			},
+		},
+	})
+}
@@ -1,10 +1,14 @@
 package grafana
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
 	"crypto/sha256"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"net/url"
 	"strconv"
 	"strings"
@@ -31,8 +35,13 @@ Manages Grafana dashboards.
 		UpdateContext: UpdateDashboard,
 		DeleteContext: DeleteDashboard,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				// validate_references isn't stored in the API, set its default value
+				d.Set("validate_references", false)
+				return []*schema.ResourceData{d}, nil
+			},
 		},
+		CustomizeDiff: validateDashboardInputs,
 
 		Schema: map[string]*schema.Schema{
 			"uid": {
@@ -75,11 +84,28 @@ Manages Grafana dashboards.
 				},
 			},
 			"config_json": {
+				Type:             schema.TypeString,
+				Required:         true,
+				StateFunc:        normalizeDashboardConfigJSON,
+				ValidateFunc:     validateDashboardConfigJSON,
+				DiffSuppressFunc: suppressDashboardConfigJSONDiff,
+				Description:      "The complete dashboard model JSON.",
+			},
+			"config_json_storage": {
 				Type:         schema.TypeString,
-				Required:     true,
-				StateFunc:    normalizeDashboardConfigJSON,
-				ValidateFunc: validateDashboardConfigJSON,
-				Description:  "The complete dashboard model JSON.",
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(dashboardStorageModes, false),
+				Description: "How `config_json` is stored in the Terraform state. `full` stores the dashboard model JSON, `sha256` stores only its SHA256 hash, " +
+					"and `gzip` stores the dashboard model JSON gzip compressed and base64 encoded, which keeps large dashboards small in the state while their changes are still compared. " +
+					"Defaults to `sha256` if `store_dashboard_sha256` is set on the provider, `full` otherwise.",
+			},
+			"inputs": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Description: "Values of the inputs of a dashboard exported for sharing externally, by input name, such as `DS_PROMETHEUS`. " +
+					"The `${INPUT_NAME}` placeholders of `config_json` are replaced by the values, and its `__inputs` and `__requires` sections are removed before the dashboard is saved. " +
+					"Datasource inputs take the UID of the datasource. Constant inputs default to the value they were exported with.",
 			},
 			"overwrite": {
 				Type:        schema.TypeBool,
@@ -91,6 +117,14 @@ Manages Grafana dashboards.
 				Optional:    true,
 				Description: "Set a commit message for the version history.",
 			},
+			"validate_references": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Set to true to check, before saving the dashboard, that the datasources and library panels referenced by its panels, " +
+					"targets, template variables and annotations exist in the organization. The JSON path of each missing reference is reported. " +
+					"References set by template variables and built-in datasources are not checked.",
+			},
 		},
 		SchemaVersion: 1,
 		StateUpgraders: []schema.StateUpgrader{
@@ -176,11 +210,16 @@ func resourceDashboardStateUpgradeV0(ctx context.Context, rawState map[string]in
 }
 
 func CreateDashboard(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*client).gapi
 	dashboard, err := makeDashboard(d)
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	if d.Get("validate_references").(bool) {
+		if diags := meta.(*client).validateDashboardReferences(ctx, dashboard.Model); diags.HasError() {
+			return diags
+		}
+	}
+	client := meta.(*client).gapi
 	resp, err := client.NewDashboard(dashboard)
 	if err != nil {
 		return diag.FromErr(err)
@@ -233,14 +272,14 @@ func ReadDashboard(ctx context.Context, d *schema.ResourceData, meta interface{}
 
 	configJSON := d.Get("config_json").(string)
 
-	// Skip if configJSON string is a sha256 hash
+	// Skip if only the sha256 hash of configJSON is stored
 	// If `uid` is not set in configuration, we need to delete it from the
 	// dashboard JSON we just read from the Grafana API. This is so it does not
 	// create a diff. We can assume the uid was randomly generated by Grafana or
 	// it was removed after dashboard creation. In any case, the user doesn't
 	// care to manage it.
-	if configJSON != "" && !sha256Regexp.MatchString(configJSON) {
-		configuredDashJSON, err := unmarshalDashboardConfigJSON(configJSON)
+	if decodedJSON, ok := decodeDashboardConfigJSON(configJSON); configJSON != "" && ok {
+		configuredDashJSON, err := unmarshalDashboardConfigJSON(decodedJSON)
 		if err != nil {
 			return diag.FromErr(err)
 		}
@@ -249,17 +288,26 @@ func ReadDashboard(ctx context.Context, d *schema.ResourceData, meta interface{}
 		}
 	}
 	configJSON = normalizeDashboardConfigJSON(remoteDashJSON)
-	d.Set("config_json", configJSON)
+	d.Set("config_json", encodeDashboardConfigJSON(configJSON, dashboardStorageMode(d, meta)))
 
 	return diags
 }
 
 func UpdateDashboard(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*client).gapi
+	// Changing how config_json is stored doesn't change the dashboard
+	if !d.HasChangeExcept("config_json_storage") {
+		return ReadDashboard(ctx, d, meta)
+	}
 	dashboard, err := makeDashboard(d)
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	if d.Get("validate_references").(bool) {
+		if diags := meta.(*client).validateDashboardReferences(ctx, dashboard.Model); diags.HasError() {
+			return diags
+		}
+	}
+	client := meta.(*client).gapi
 	dashboard.Model["id"] = d.Get("dashboard_id").(int)
 	dashboard.Overwrite = true
 	resp, err := client.NewDashboard(dashboard)
@@ -302,12 +350,132 @@ func makeDashboard(d *schema.ResourceData) (gapi.Dashboard, error) {
 	if err != nil {
 		return dashboard, err
 	}
+	if err := resolveDashboardInputs(dashboardJSON, d.Get("inputs").(map[string]interface{})); err != nil {
+		return dashboard, err
+	}
 	delete(dashboardJSON, "id")
 	delete(dashboardJSON, "version")
 	dashboard.Model = dashboardJSON
 	return dashboard, nil
 }
 
+// resolveDashboardInputs replaces the `${INPUT_NAME}` placeholders of a dashboard exported for sharing externally
+// with the values of its inputs, and removes the sections that describe the inputs and requirements of the export.
+// Dashboards without `__inputs` are left unchanged.
+func resolveDashboardInputs(dashboardJSON map[string]interface{}, inputs map[string]interface{}) error {
+	exportedInputs, ok := dashboardJSON["__inputs"].([]interface{})
+	if !ok {
+		return nil
+	}
+
+	values := map[string]string{}
+	var unresolved []string
+	for _, i := range exportedInputs {
+		input, ok := i.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _ := input["name"].(string)
+		if value, ok := inputs[name]; ok {
+			values[name] = value.(string)
+			continue
+		}
+		if value, _ := input["value"].(string); input["type"] == "constant" && value != "" {
+			values[name] = value
+			continue
+		}
+		unresolved = append(unresolved, name)
+	}
+	if len(unresolved) > 0 {
+		return fmt.Errorf("the following dashboard inputs have no value: %s. Set them in `inputs`", strings.Join(unresolved, ", "))
+	}
+
+	delete(dashboardJSON, "__inputs")
+	delete(dashboardJSON, "__requires")
+	for k, v := range dashboardJSON {
+		dashboardJSON[k] = replaceDashboardInputs(v, values)
+	}
+	return nil
+}
+
+// replaceDashboardInputs replaces the `${INPUT_NAME}` placeholders in all the strings of a dashboard model value.
+func replaceDashboardInputs(value interface{}, values map[string]string) interface{} {
+	switch v := value.(type) {
+	case string:
+		for name, inputValue := range values {
+			v = strings.ReplaceAll(v, "${"+name+"}", inputValue)
+		}
+		return v
+	case map[string]interface{}:
+		for k, item := range v {
+			v[k] = replaceDashboardInputs(item, values)
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = replaceDashboardInputs(item, values)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// validateDashboardInputs fails the plan when inputs of the configured dashboard have no value.
+func validateDashboardInputs(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	config := d.GetRawConfig()
+	if config.IsNull() {
+		return nil
+	}
+	configJSON, inputs := config.GetAttr("config_json"), config.GetAttr("inputs")
+	// Values that are unknown until apply can't be checked
+	if !configJSON.IsKnown() || configJSON.IsNull() || !inputs.IsWhollyKnown() {
+		return nil
+	}
+	dashboardJSON, err := unmarshalDashboardConfigJSON(configJSON.AsString())
+	if err != nil {
+		// Invalid JSON is reported by validateDashboardConfigJSON
+		return nil
+	}
+	return resolveDashboardInputs(dashboardJSON, d.Get("inputs").(map[string]interface{}))
+}
+
+// suppressDashboardConfigJSONDiff is the DiffSuppressFunc for `config_json`. The dashboard model in state is
+// decoded according to its storage mode, and compared to the configured dashboard model. The dashboard read
+// from Grafana has its inputs resolved, so the configured dashboard is compared once its inputs are resolved.
+func suppressDashboardConfigJSONDiff(k, old, new string, d *schema.ResourceData) bool {
+	if old == "" {
+		return false
+	}
+	if resolvedJSON, ok := resolvedDashboardConfigJSON(d); ok {
+		new = resolvedJSON
+	}
+	if decodedJSON, ok := decodeDashboardConfigJSON(old); ok {
+		return decodedJSON == new
+	}
+	return old == encodeDashboardConfigJSON(new, dashboardStorageSHA256)
+}
+
+// resolvedDashboardConfigJSON returns the configured dashboard model, normalized and with its inputs resolved,
+// if it has inputs that can be resolved.
+func resolvedDashboardConfigJSON(d *schema.ResourceData) (string, bool) {
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.GetAttr("config_json").IsKnown() || config.GetAttr("config_json").IsNull() {
+		return "", false
+	}
+	dashboardJSON, err := unmarshalDashboardConfigJSON(config.GetAttr("config_json").AsString())
+	if err != nil {
+		return "", false
+	}
+	if _, ok := dashboardJSON["__inputs"]; !ok {
+		return "", false
+	}
+	if err := resolveDashboardInputs(dashboardJSON, d.Get("inputs").(map[string]interface{})); err != nil {
+		return "", false
+	}
+	return normalizeDashboardConfigJSON(dashboardJSON), true
+}
+
 // unmarshalDashboardConfigJSON is a convenience func for unmarshalling
 // `config_json` field.
 func unmarshalDashboardConfigJSON(configJSON string) (map[string]interface{}, error) {
@@ -374,11 +542,59 @@ func normalizeDashboardConfigJSON(config interface{}) string {
 	}
 
 	j, _ := json.Marshal(dashboardJSON)
+	return string(j)
+}
 
-	if storeDashboardSHA256 {
-		configHash := sha256.Sum256(j)
+// Storage modes of `config_json` in the state.
+const (
+	dashboardStorageFull   = "full"
+	dashboardStorageSHA256 = "sha256"
+	dashboardStorageGzip   = "gzip"
+)
+
+var dashboardStorageModes = []string{dashboardStorageFull, dashboardStorageSHA256, dashboardStorageGzip}
+
+// dashboardStorageMode returns the storage mode of the dashboard's `config_json`, which defaults to the provider's.
+func dashboardStorageMode(d *schema.ResourceData, meta interface{}) string {
+	if mode := d.Get("config_json_storage").(string); mode != "" {
+		return mode
+	}
+	if meta.(*client).storeDashboardSHA256 {
+		return dashboardStorageSHA256
+	}
+	return dashboardStorageFull
+}
+
+// encodeDashboardConfigJSON returns the normalized dashboard model JSON as it is stored in the state.
+func encodeDashboardConfigJSON(configJSON, mode string) string {
+	switch mode {
+	case dashboardStorageSHA256:
+		configHash := sha256.Sum256([]byte(configJSON))
 		return fmt.Sprintf("%x", configHash[:])
-	} else {
-		return string(j)
+	case dashboardStorageGzip:
+		var buf bytes.Buffer
+		w := gzip.NewWriter(&buf)
+		w.Write([]byte(configJSON))
+		w.Close()
+		return base64.StdEncoding.EncodeToString(buf.Bytes())
+	default:
+		return configJSON
+	}
+}
+
+// decodeDashboardConfigJSON returns the dashboard model JSON stored in the state, whatever its storage mode.
+// It returns false if only the sha256 hash of the dashboard model is stored.
+func decodeDashboardConfigJSON(stored string) (string, bool) {
+	if sha256Regexp.MatchString(stored) {
+		return "", false
+	}
+	// JSON isn't valid base64, so only compressed dashboard models are decoded
+	if data, err := base64.StdEncoding.DecodeString(stored); err == nil {
+		if r, err := gzip.NewReader(bytes.NewReader(data)); err == nil {
+			if j, err := ioutil.ReadAll(r); err == nil {
+				return string(j), true
+			}
+		}
 	}
+	return stored, true
 }
@@ -3,12 +3,15 @@ package grafana
 import (
 	"fmt"
 	"os"
+	"reflect"
+	"regexp"
 	"strings"
 	"testing"
 
 	gapi "github.com/grafana/grafana-api-golang-client"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
@@ -234,6 +237,213 @@ func testAccDashboardFolderCheckDestroy(dashboard *gapi.Dashboard, folder *gapi.
 	}
 }
 
+func TestAccDashboard_inputs(t *testing.T) {
+	CheckOSSTestsEnabled(t)
+
+	var dashboard gapi.Dashboard
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccDashboardCheckDestroy(&dashboard),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExample(t, "resources/grafana_dashboard/_acc_inputs.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDashboardCheckExists("grafana_dashboard.test", &dashboard),
+					resource.TestCheckResourceAttr("grafana_dashboard.test", "uid", "inputs"),
+					func(s *terraform.State) error {
+						datasourceUID := s.RootModule().Resources["grafana_data_source.inputs"].Primary.Attributes["uid"]
+						if _, ok := dashboard.Model["__inputs"]; ok {
+							return fmt.Errorf("expected __inputs to be removed from the dashboard")
+						}
+						if _, ok := dashboard.Model["__requires"]; ok {
+							return fmt.Errorf("expected __requires to be removed from the dashboard")
+						}
+						panel := dashboard.Model["panels"].([]interface{})[0].(map[string]interface{})
+						if uid := panel["datasource"].(map[string]interface{})["uid"]; uid != datasourceUID {
+							return fmt.Errorf("expected the panel datasource to be %q, got %q", datasourceUID, uid)
+						}
+						if expr := panel["targets"].([]interface{})[0].(map[string]interface{})["expr"]; expr != `up{job="node"}` {
+							return fmt.Errorf("expected the constant input to be resolved, got %q", expr)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: `
+resource "grafana_dashboard" "test" {
+  config_json = jsonencode({
+    __inputs = [{ name = "DS_PROMETHEUS", type = "datasource", pluginId = "prometheus" }]
+    title    = "Terraform Inputs Test"
+  })
+}`,
+				ExpectError: regexp.MustCompile("the following dashboard inputs have no value: DS_PROMETHEUS"),
+			},
+		},
+	})
+}
+
+func TestAccDashboard_validateReferences(t *testing.T) {
+	CheckOSSTestsEnabled(t)
+
+	var dashboard gapi.Dashboard
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccDashboardCheckDestroy(&dashboard),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExample(t, "resources/grafana_dashboard/_acc_validate_references.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDashboardCheckExists("grafana_dashboard.test", &dashboard),
+					resource.TestCheckResourceAttr("grafana_dashboard.test", "validate_references", "true"),
+				),
+			},
+			{
+				Config: `
+resource "grafana_dashboard" "test" {
+  validate_references = true
+  config_json = jsonencode({
+    title = "Terraform Validate References Test"
+    uid   = "validate-references"
+    panels = [{
+      datasource   = { type = "prometheus", uid = "does-not-exist" }
+      libraryPanel = { uid = "does-not-exist", name = "does-not-exist" }
+    }]
+  })
+}`,
+				ExpectError: regexp.MustCompile(`The dashboard references a datasource that doesn't exist: "does-not-exist"`),
+			},
+		},
+	})
+}
+
+func TestAccDashboard_storage(t *testing.T) {
+	CheckOSSTestsEnabled(t)
+
+	var dashboard gapi.Dashboard
+	config := func(storage string) string {
+		return fmt.Sprintf(`
+resource "grafana_dashboard" "test" {
+  config_json_storage = "%s"
+  config_json = jsonencode({
+    title = "Terraform Storage Test"
+    uid   = "storage"
+  })
+}`, storage)
+	}
+	expectedConfig := `{"title":"Terraform Storage Test","uid":"storage"}`
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccDashboardCheckDestroy(&dashboard),
+		Steps: []resource.TestStep{
+			{
+				Config: config("gzip"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDashboardCheckExists("grafana_dashboard.test", &dashboard),
+					func(s *terraform.State) error {
+						value := s.RootModule().Resources["grafana_dashboard.test"].Primary.Attributes["config_json"]
+						if decoded, _ := decodeDashboardConfigJSON(value); value == expectedConfig || decoded != expectedConfig {
+							return fmt.Errorf("expected config_json to be the compressed dashboard model, got %s", value)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: config("sha256"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccDashboardCheckExists("grafana_dashboard.test", &dashboard),
+					resource.TestCheckResourceAttr("grafana_dashboard.test", "config_json", encodeDashboardConfigJSON(expectedConfig, dashboardStorageSHA256)),
+					// Changing the storage doesn't save a new version of the dashboard
+					resource.TestCheckResourceAttr("grafana_dashboard.test", "version", "1"),
+				),
+			},
+			{
+				Config: config("full"),
+				Check:  resource.TestCheckResourceAttr("grafana_dashboard.test", "config_json", expectedConfig),
+			},
+		},
+	})
+}
+
+func TestDashboardConfigJSONStorageUnit(t *testing.T) {
+	IsUnitTest(t)
+
+	configJSON := `{"panels":[{"title":"panel","type":"text"}],"title":"Storage"}`
+	for _, mode := range dashboardStorageModes {
+		t.Run(mode, func(t *testing.T) {
+			stored := encodeDashboardConfigJSON(configJSON, mode)
+			decoded, ok := decodeDashboardConfigJSON(stored)
+			switch mode {
+			case dashboardStorageSHA256:
+				if ok || !sha256Regexp.MatchString(stored) {
+					t.Errorf("expected only the hash to be stored, got %s", stored)
+				}
+			case dashboardStorageGzip:
+				if stored == configJSON || !ok || decoded != configJSON {
+					t.Errorf("expected the compressed model to be stored and decoded, got %s decoded to %s", stored, decoded)
+				}
+			default:
+				if stored != configJSON || !ok || decoded != configJSON {
+					t.Errorf("expected the model to be stored as is, got %s decoded to %s", stored, decoded)
+				}
+			}
+
+			d := schema.TestResourceDataRaw(t, ResourceDashboard().Schema, map[string]interface{}{})
+			if !suppressDashboardConfigJSONDiff("config_json", stored, configJSON, d) {
+				t.Errorf("expected no diff between %s and the stored model %s", configJSON, stored)
+			}
+			if suppressDashboardConfigJSONDiff("config_json", stored, `{"title":"Changed"}`, d) {
+				t.Errorf("expected a diff between a changed model and the stored model %s", stored)
+			}
+		})
+	}
+}
+
+func TestResolveDashboardInputsUnit(t *testing.T) {
+	IsUnitTest(t)
+
+	dashboardJSON, err := unmarshalDashboardConfigJSON(`{
+		"__inputs": [
+			{"name": "DS_PROMETHEUS", "type": "datasource", "pluginId": "prometheus", "value": ""},
+			{"name": "VAR_JOB", "type": "constant", "value": "node"},
+			{"name": "VAR_ENV", "type": "constant", "value": "dev"}
+		],
+		"__requires": [{"type": "datasource", "id": "prometheus"}],
+		"title": "${VAR_ENV} overview",
+		"panels": [{"datasource": {"uid": "${DS_PROMETHEUS}"}, "targets": [{"expr": "up{job=\"${VAR_JOB}\"}"}]}]
+	}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, err := unmarshalDashboardConfigJSON(`{
+		"title": "prod overview",
+		"panels": [{"datasource": {"uid": "prom-uid"}, "targets": [{"expr": "up{job=\"node\"}"}]}]
+	}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := resolveDashboardInputs(dashboardJSON, map[string]interface{}{"DS_PROMETHEUS": "prom-uid", "VAR_ENV": "prod"}); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(dashboardJSON, expected) {
+		t.Errorf("expected %v, got %v", expected, dashboardJSON)
+	}
+
+	unresolved, err := unmarshalDashboardConfigJSON(`{"__inputs": [{"name": "DS_PROMETHEUS", "type": "datasource"}, {"name": "VAR_JOB", "type": "constant", "value": ""}]}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = resolveDashboardInputs(unresolved, nil)
+	if err == nil || err.Error() != "the following dashboard inputs have no value: DS_PROMETHEUS, VAR_JOB. Set them in `inputs`" {
+		t.Errorf("expected an error listing the unresolved inputs, got %v", err)
+	}
+}
+
 func Test_normalizeDashboardConfigJSON(t *testing.T) {
 	IsUnitTest(t)
 
@@ -21,10 +21,11 @@ func ResourceFolder() *schema.Resource {
 `,
 
 		CreateContext: CreateFolder,
+		UpdateContext: UpdateFolder,
 		DeleteContext: DeleteFolder,
 		ReadContext:   ReadFolder,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStateWithDeleteProtection,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -51,6 +52,7 @@ func ResourceFolder() *schema.Resource {
 				Computed:    true,
 				Description: "The full URL of the folder.",
 			},
+			"delete_protection": deleteProtectionSchema("folder"),
 		},
 	}
 }
@@ -107,8 +109,16 @@ func ReadFolder(ctx context.Context, d *schema.ResourceData, meta interface{}) d
 	return nil
 }
 
+// UpdateFolder only handles the provider-side settings. Other changes recreate the folder.
+func UpdateFolder(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return ReadFolder(ctx, d, meta)
+}
+
 func DeleteFolder(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*client).gapi
+	if diag := checkDeleteProtection(d, "folder", d.Get("title").(string)); diag != nil {
+		return diag
+	}
 
 	if err := client.DeleteFolder(d.Get("uid").(string)); err != nil {
 		return diag.FromErr(err)
@@ -3,6 +3,7 @@ package grafana
 import (
 	"fmt"
 	"os"
+	"regexp"
 	"strconv"
 	"strings"
 	"testing"
@@ -96,3 +97,42 @@ func testAccFolderCheckDestroy(folder *gapi.Folder) resource.TestCheckFunc {
 		return nil
 	}
 }
+
+func TestAccFolder_deleteProtection(t *testing.T) {
+	CheckOSSTestsEnabled(t)
+
+	var folder gapi.Folder
+	config := func(deleteProtection bool) string {
+		return fmt.Sprintf(`
+resource "grafana_folder" "protected" {
+  title             = "Terraform Protected Folder"
+  delete_protection = %t
+}`, deleteProtection)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccFolderCheckDestroy(&folder),
+		Steps: []resource.TestStep{
+			{
+				Config: config(true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccFolderCheckExists("grafana_folder.protected", &folder),
+					resource.TestCheckResourceAttr("grafana_folder.protected", "delete_protection", "true"),
+				),
+			},
+			{
+				// Removing the resource from the config must fail while the folder is protected
+				Config:      `# no resources`,
+				ExpectError: regexp.MustCompile(`cannot delete folder "Terraform Protected Folder": delete_protection is enabled`),
+			},
+			{
+				Config: config(false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccFolderCheckExists("grafana_folder.protected", &folder),
+					resource.TestCheckResourceAttr("grafana_folder.protected", "delete_protection", "false"),
+				),
+			},
+		},
+	})
+}
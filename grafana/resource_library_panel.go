@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -18,6 +19,9 @@ func ResourceLibraryPanel() *schema.Resource {
 		Description: `
 Manages Grafana library panels.
 
+Changes to ` + "`model_json`" + ` are displayed by all the dashboards connected to the library panel.
+The connected dashboards are listed, by title and UID, in the provider's warning logs when the change is planned and in a warning when it is applied.
+
 * [Official documentation](https://grafana.com/docs/grafana/latest/panels/panel-library/)
 * [HTTP API](https://grafana.com/docs/grafana/latest/http_api/library_element/)
 `,
@@ -27,8 +31,13 @@ Manages Grafana library panels.
 		UpdateContext: UpdateLibraryPanel,
 		DeleteContext: DeleteLibraryPanel,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				// force_delete isn't stored in the API, set its default value
+				d.Set("force_delete", false)
+				return []*schema.ResourceData{d}, nil
+			},
 		},
+		CustomizeDiff: customizeDiffLibraryPanel,
 
 		Schema: map[string]*schema.Schema{
 			"uid": {
@@ -107,6 +116,14 @@ Manages Grafana library panels.
 				Description: "Numerical IDs of Grafana dashboards containing the library panel.",
 				Elem:        &schema.Schema{Type: schema.TypeInt},
 			},
+			"force_delete": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Whether to delete the library panel even if it is connected to dashboards. " +
+					"If true, the library panel is removed from the connected dashboards before being deleted. " +
+					"If false, deleting a library panel that is connected to dashboards fails.",
+			},
 		},
 	}
 }
@@ -187,25 +204,140 @@ func UpdateLibraryPanel(ctx context.Context, d *schema.ResourceData, meta interf
 	uid := d.Id()
 	panel := makeLibraryPanel(d)
 
+	var diags diag.Diagnostics
+	if d.HasChange("model_json") {
+		dashboards, err := libraryPanelConnectedDashboards(client, uid)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if len(dashboards) > 0 {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("The model of library panel %q was changed while it is connected to dashboards", panel.Name),
+				Detail:   "The following dashboards display the updated library panel: " + formatLibraryPanelDashboards(dashboards),
+			})
+		}
+	}
+
 	resp, err := client.PatchLibraryPanel(uid, panel)
 	if err != nil {
-		return diag.FromErr(err)
+		return append(diags, diag.FromErr(err)...)
 	}
 	d.SetId(resp.UID)
 	d.Set("uid", resp.UID)
-	return ReadLibraryPanel(ctx, d, meta)
+	return append(diags, ReadLibraryPanel(ctx, d, meta)...)
 }
 
 func DeleteLibraryPanel(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*client).gapi
 	uid := d.Id()
-	_, err := client.DeleteLibraryPanel(uid)
+
+	dashboards, err := libraryPanelConnectedDashboards(client, uid)
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	if len(dashboards) > 0 {
+		if !d.Get("force_delete").(bool) {
+			return diag.Errorf("cannot delete library panel %q: it is connected to the following dashboards: %s. "+
+				"Remove it from the dashboards or set force_delete to true and apply before deleting it", d.Get("name").(string), formatLibraryPanelDashboards(dashboards))
+		}
+		for _, dashboard := range dashboards {
+			if err := removeLibraryPanelFromDashboard(client, dashboard.UID, uid); err != nil {
+				return diag.Errorf("error removing library panel %q from dashboard %q (%s): %s", d.Get("name").(string), dashboard.Title, dashboard.UID, err)
+			}
+		}
+	}
+
+	_, err = client.DeleteLibraryPanel(uid)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// customizeDiffLibraryPanel logs the dashboards affected by a change of the panel model.
+// Plan time warnings can't be shown by the plugin SDK, so they are also returned when the change is applied.
+func customizeDiffLibraryPanel(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !d.HasChange("model_json") {
+		return nil
+	}
+	dashboards, err := libraryPanelConnectedDashboards(meta.(*client).gapi, d.Id())
+	if err != nil {
+		// Don't fail the plan, the connections are only used for information
+		log.Printf("[WARN] could not get the dashboards connected to library panel %s: %s", d.Id(), err)
+		return nil
+	}
+	if len(dashboards) > 0 {
+		log.Printf("[WARN] the model of library panel %s changes, which affects the following dashboards: %s", d.Id(), formatLibraryPanelDashboards(dashboards))
+	}
 	return nil
 }
 
+// libraryPanelConnectedDashboards returns the dashboards connected to the library panel.
+func libraryPanelConnectedDashboards(client *gapi.Client, uid string) ([]gapi.FolderDashboardSearchResponse, error) {
+	connections, err := client.LibraryPanelConnections(uid)
+	if err != nil {
+		return nil, err
+	}
+	// Searching without IDs would return all dashboards
+	if len(*connections) == 0 {
+		return nil, nil
+	}
+	ids := make([]int64, 0, len(*connections))
+	for _, connection := range *connections {
+		ids = append(ids, connection.DashboardID)
+	}
+	return client.DashboardsByIDs(ids)
+}
+
+func formatLibraryPanelDashboards(dashboards []gapi.FolderDashboardSearchResponse) string {
+	names := make([]string, 0, len(dashboards))
+	for _, dashboard := range dashboards {
+		names = append(names, fmt.Sprintf("%q (%s)", dashboard.Title, dashboard.UID))
+	}
+	return strings.Join(names, ", ")
+}
+
+// removeLibraryPanelFromDashboard saves the dashboard without the panels that are instances of the library panel.
+func removeLibraryPanelFromDashboard(client *gapi.Client, dashboardUID, panelUID string) error {
+	dashboard, err := client.DashboardByUID(dashboardUID)
+	if err != nil {
+		return err
+	}
+	dashboard.Model["panels"] = removeLibraryPanelFromPanels(dashboard.Model["panels"], panelUID)
+	_, err = client.NewDashboard(gapi.Dashboard{
+		Model:     dashboard.Model,
+		Folder:    dashboard.Folder,
+		Overwrite: true,
+		Message:   fmt.Sprintf("Removed library panel %s", panelUID),
+	})
+	return err
+}
+
+// removeLibraryPanelFromPanels removes the instances of the library panel from the panels, including the panels of collapsed rows.
+func removeLibraryPanelFromPanels(panels interface{}, panelUID string) interface{} {
+	list, ok := panels.([]interface{})
+	if !ok {
+		return panels
+	}
+	kept := make([]interface{}, 0, len(list))
+	for _, panel := range list {
+		p, ok := panel.(map[string]interface{})
+		if !ok {
+			kept = append(kept, panel)
+			continue
+		}
+		if libraryPanel, ok := p["libraryPanel"].(map[string]interface{}); ok && libraryPanel["uid"] == panelUID {
+			continue
+		}
+		if rowPanels, ok := p["panels"]; ok {
+			p["panels"] = removeLibraryPanelFromPanels(rowPanels, panelUID)
+		}
+		kept = append(kept, p)
+	}
+	return kept
+}
+
 func makeLibraryPanel(d *schema.ResourceData) gapi.LibraryPanel {
 	modelJSON := d.Get("model_json").(string)
 	panelJSON, err := unmarshalLibraryPanelModelJSON(modelJSON)
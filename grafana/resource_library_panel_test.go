@@ -2,6 +2,8 @@ package grafana
 
 import (
 	"fmt"
+	"reflect"
+	"regexp"
 	"testing"
 
 	gapi "github.com/grafana/grafana-api-golang-client"
@@ -174,3 +176,113 @@ func testAccLibraryPanelFolderCheckDestroy(panel *gapi.LibraryPanel, folder *gap
 		return nil
 	}
 }
+
+func TestAccLibraryPanel_forceDelete(t *testing.T) {
+	CheckOSSTestsEnabled(t)
+	CheckOSSTestsSemver(t, ">=8.0.0")
+
+	var panel gapi.LibraryPanel
+	var dashboard gapi.Dashboard
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccLibraryPanelCheckDestroy(&panel),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccLibraryPanelConnectedConfig(false, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccLibraryPanelCheckExists("grafana_library_panel.test", &panel),
+					testAccDashboardCheckExists("grafana_dashboard.test", &dashboard),
+					resource.TestCheckResourceAttr("grafana_library_panel.test", "force_delete", "false"),
+				),
+			},
+			{
+				// Deleting a connected library panel fails without force_delete
+				Config:      testAccLibraryPanelConnectedConfig(false, false),
+				ExpectError: regexp.MustCompile(`cannot delete library panel "force-delete": it is connected to the following dashboards: "force-delete" \(force-delete\)`),
+			},
+			{
+				Config: testAccLibraryPanelConnectedConfig(true, true),
+				Check:  resource.TestCheckResourceAttr("grafana_library_panel.test", "force_delete", "true"),
+			},
+			{
+				// The library panel is removed from the dashboard, which then differs from its configuration
+				Config:             testAccLibraryPanelConnectedConfig(true, false),
+				ExpectNonEmptyPlan: true,
+				Check: func(s *terraform.State) error {
+					client := testAccProvider.Meta().(*client).gapi
+					dashboard, err := client.DashboardByUID("force-delete")
+					if err != nil {
+						return err
+					}
+					if panels := dashboard.Model["panels"].([]interface{}); len(panels) != 0 {
+						return fmt.Errorf("expected the library panel to be removed from the dashboard, got panels: %v", panels)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+// testAccLibraryPanelConnectedConfig returns a dashboard that displays a library panel. The dashboard references
+// the library panel by a literal UID, so that the library panel can be removed from the configuration alone.
+func testAccLibraryPanelConnectedConfig(forceDelete, withPanel bool) string {
+	config := `
+resource "grafana_dashboard" "test" {
+  config_json = jsonencode({
+    uid   = "force-delete"
+    title = "force-delete"
+    panels = [{
+      gridPos      = { x = 0, y = 0, h = 10, w = 10 }
+      libraryPanel = { uid = "force-delete", name = "force-delete" }
+    }]
+  })
+}
+`
+	if withPanel {
+		config += fmt.Sprintf(`
+resource "grafana_library_panel" "test" {
+  uid          = "force-delete"
+  name         = "force-delete"
+  force_delete = %t
+  model_json = jsonencode({
+    title = "force-delete"
+    type  = "text"
+  })
+}
+`, forceDelete)
+	}
+	return config
+}
+
+func TestRemoveLibraryPanelFromPanelsUnit(t *testing.T) {
+	IsUnitTest(t)
+
+	panels := []interface{}{
+		map[string]interface{}{"id": 1.0, "libraryPanel": map[string]interface{}{"uid": "removed"}},
+		map[string]interface{}{"id": 2.0, "libraryPanel": map[string]interface{}{"uid": "kept"}},
+		map[string]interface{}{
+			"id":   3.0,
+			"type": "row",
+			"panels": []interface{}{
+				map[string]interface{}{"id": 4.0, "libraryPanel": map[string]interface{}{"uid": "removed"}},
+				map[string]interface{}{"id": 5.0, "type": "text"},
+			},
+		},
+	}
+	expected := []interface{}{
+		map[string]interface{}{"id": 2.0, "libraryPanel": map[string]interface{}{"uid": "kept"}},
+		map[string]interface{}{
+			"id":   3.0,
+			"type": "row",
+			"panels": []interface{}{
+				map[string]interface{}{"id": 5.0, "type": "text"},
+			},
+		},
+	}
+
+	if actual := removeLibraryPanelFromPanels(panels, "removed"); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected %v, got %v", expected, actual)
+	}
+}
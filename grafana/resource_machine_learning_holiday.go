@@ -0,0 +1,196 @@
+package grafana
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func ResourceMachineLearningHoliday() *schema.Resource {
+	return &schema.Resource{
+
+		Description: `
+A holiday describes time periods where a time series is expected to behave differently to normal.
+
+To use a holiday in a job, add its id to the ` + "`holidays`" + ` attribute of a ` + "`grafana_machine_learning_job`" + `.
+`,
+
+		CreateContext: resourceMachineLearningHolidayCreate,
+		ReadContext:   resourceMachineLearningHolidayRead,
+		UpdateContext: resourceMachineLearningHolidayUpdate,
+		DeleteContext: resourceMachineLearningHolidayDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Description: "The ID of the holiday.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"name": {
+				Description: "The name of the holiday.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"description": {
+				Description: "A description of the holiday.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"ical_url": {
+				Description:  "A URL to an iCal file containing all occurrences of the holiday.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"ical_url", "custom_periods"},
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+			},
+			"ical_timezone": {
+				Description:  "The timezone to use for events in the iCal file pointed to by `ical_url`.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"ical_url"},
+			},
+			"custom_periods": {
+				Description: "A list of custom periods for the holiday.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Description: "The name of the custom period.",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+						"start_time": {
+							Description:      "The start time of the custom period, in RFC3339 format.",
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateFunc:     validation.IsRFC3339Time,
+							DiffSuppressFunc: suppressMLHolidayTimeDiff,
+						},
+						"end_time": {
+							Description:      "The end time of the custom period, in RFC3339 format.",
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateFunc:     validation.IsRFC3339Time,
+							DiffSuppressFunc: suppressMLHolidayTimeDiff,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceMachineLearningHolidayCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*client).mlapi
+	holiday, err := makeMLHoliday(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	holiday, err = c.NewHoliday(ctx, holiday)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(holiday.ID)
+	return resourceMachineLearningHolidayRead(ctx, d, meta)
+}
+
+func resourceMachineLearningHolidayRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*client).mlapi
+	holiday, err := c.Holiday(ctx, d.Id())
+	if err != nil {
+		if strings.Contains(err.Error(), "status: 404") {
+			log.Printf("[WARN] removing holiday %s from state because it no longer exists in grafana", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	customPeriods := make([]interface{}, 0, len(holiday.CustomPeriods))
+	for _, p := range holiday.CustomPeriods {
+		customPeriods = append(customPeriods, map[string]interface{}{
+			"name":       p.Name,
+			"start_time": p.StartTime.Format(time.RFC3339),
+			"end_time":   p.EndTime.Format(time.RFC3339),
+		})
+	}
+
+	d.Set("name", holiday.Name)
+	d.Set("description", holiday.Description)
+	d.Set("ical_url", holiday.ICalURL)
+	d.Set("ical_timezone", holiday.ICalTimeZone)
+	d.Set("custom_periods", customPeriods)
+
+	return nil
+}
+
+func resourceMachineLearningHolidayUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*client).mlapi
+	holiday, err := makeMLHoliday(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if _, err := c.UpdateHoliday(ctx, holiday); err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceMachineLearningHolidayRead(ctx, d, meta)
+}
+
+func resourceMachineLearningHolidayDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*client).mlapi
+	if err := c.DeleteHoliday(ctx, d.Id()); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId("")
+	return nil
+}
+
+// suppressMLHolidayTimeDiff ignores differences in the offset of custom period times, as the API may return them in
+// another time zone than the configured one.
+func suppressMLHolidayTimeDiff(k, old, new string, d *schema.ResourceData) bool {
+	oldParsed, err := time.Parse(time.RFC3339, old)
+	if err != nil {
+		return false
+	}
+	newParsed, err := time.Parse(time.RFC3339, new)
+	if err != nil {
+		return false
+	}
+	return oldParsed.Equal(newParsed)
+}
+
+func makeMLHoliday(d *schema.ResourceData) (mlHoliday, error) {
+	holiday := mlHoliday{
+		ID:           d.Id(),
+		Name:         d.Get("name").(string),
+		Description:  d.Get("description").(string),
+		ICalURL:      d.Get("ical_url").(string),
+		ICalTimeZone: d.Get("ical_timezone").(string),
+	}
+	for _, p := range d.Get("custom_periods").([]interface{}) {
+		p := p.(map[string]interface{})
+		start, err := time.Parse(time.RFC3339, p["start_time"].(string))
+		if err != nil {
+			return holiday, err
+		}
+		end, err := time.Parse(time.RFC3339, p["end_time"].(string))
+		if err != nil {
+			return holiday, err
+		}
+		holiday.CustomPeriods = append(holiday.CustomPeriods, mlHolidayCustomPeriod{
+			Name:      p["name"].(string),
+			StartTime: start,
+			EndTime:   end,
+		})
+	}
+	return holiday, nil
+}
@@ -0,0 +1,140 @@
+package grafana
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResourceMachineLearningHoliday(t *testing.T) {
+	CheckCloudInstanceTestsEnabled(t)
+
+	var holiday mlHoliday
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccMLHolidayCheckDestroy(&holiday),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExample(t, "resources/grafana_machine_learning_holiday/resource.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccMLHolidayCheckExists("grafana_machine_learning_holiday.test_holiday", &holiday),
+					resource.TestCheckResourceAttrSet("grafana_machine_learning_holiday.test_holiday", "id"),
+					resource.TestCheckResourceAttr("grafana_machine_learning_holiday.test_holiday", "name", "Test Holiday"),
+					resource.TestCheckResourceAttr("grafana_machine_learning_holiday.test_holiday", "custom_periods.#", "2"),
+					resource.TestCheckResourceAttr("grafana_machine_learning_holiday.test_holiday", "custom_periods.0.name", "First day"),
+					resource.TestCheckResourceAttr("grafana_machine_learning_holiday.test_holiday", "custom_periods.0.start_time", "2023-01-02T00:00:00Z"),
+					resource.TestCheckResourceAttr("grafana_machine_learning_holiday.test_holiday", "custom_periods.0.end_time", "2023-01-03T00:00:00Z"),
+				),
+			},
+			{
+				Config: testAccExample(t, "resources/grafana_machine_learning_holiday/ical.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccMLHolidayCheckExists("grafana_machine_learning_holiday.test_holiday", &holiday),
+					resource.TestCheckResourceAttr("grafana_machine_learning_holiday.test_holiday", "ical_timezone", "Europe/London"),
+					resource.TestCheckResourceAttr("grafana_machine_learning_holiday.test_holiday", "custom_periods.#", "0"),
+				),
+			},
+			{
+				ResourceName:      "grafana_machine_learning_holiday.test_holiday",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceMachineLearningJobHolidays(t *testing.T) {
+	CheckCloudInstanceTestsEnabled(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExample(t, "resources/grafana_machine_learning_job/holidays_job.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("grafana_machine_learning_job.test_job", "holidays.#", "1"),
+					resource.TestCheckTypeSetElemAttrPair("grafana_machine_learning_job.test_job", "holidays.*", "grafana_machine_learning_holiday.test_holiday", "id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccMLHolidayCheckExists(rn string, holiday *mlHoliday) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[rn]
+		if !ok {
+			return fmt.Errorf("resource not found: %s\n %#v", rn, s.RootModule().Resources)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("resource id not set")
+		}
+
+		client := testAccProvider.Meta().(*client).mlapi
+		gotHoliday, err := client.Holiday(context.Background(), rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("error getting holiday: %s", err)
+		}
+
+		*holiday = gotHoliday
+
+		return nil
+	}
+}
+
+func testAccMLHolidayCheckDestroy(holiday *mlHoliday) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if holiday.ID == "" {
+			return fmt.Errorf("checking deletion of empty id")
+		}
+		client := testAccProvider.Meta().(*client).mlapi
+		_, err := client.Holiday(context.Background(), holiday.ID)
+		if err == nil {
+			return fmt.Errorf("holiday still exists on server")
+		}
+		return nil
+	}
+}
+
+func TestMLHolidayCustomPeriodOffsetUnit(t *testing.T) {
+	IsUnitTest(t)
+
+	state := &terraform.InstanceState{
+		ID: "1",
+		Attributes: map[string]string{
+			"id":                          "1",
+			"name":                        "Christmas",
+			"custom_periods.#":            "1",
+			"custom_periods.0.name":       "",
+			"custom_periods.0.start_time": "2022-12-23T23:00:00Z",
+			"custom_periods.0.end_time":   "2022-12-25T23:00:00Z",
+		},
+	}
+	for name, tc := range map[string]struct {
+		startTime string
+		diff      bool
+	}{
+		"same instant in another offset":  {"2022-12-24T00:00:00+01:00", false},
+		"same instant in the same offset": {"2022-12-23T23:00:00Z", false},
+		"other instant":                   {"2022-12-24T00:00:00Z", true},
+	} {
+		config := terraform.NewResourceConfigRaw(map[string]interface{}{
+			"name": "Christmas",
+			"custom_periods": []interface{}{map[string]interface{}{
+				"start_time": tc.startTime,
+				"end_time":   "2022-12-26T00:00:00+01:00",
+			}},
+		})
+		diff, err := ResourceMachineLearningHoliday().Diff(context.Background(), state, config, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hasDiff := diff != nil && len(diff.Attributes) > 0; hasDiff != tc.diff {
+			t.Errorf("%s: expected a diff to be %t, got %v", name, tc.diff, diff)
+		}
+	}
+}
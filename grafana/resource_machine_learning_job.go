@@ -2,10 +2,14 @@ package grafana
 
 import (
 	"context"
+	"log"
+	"strings"
 	"time"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	"github.com/grafana/machine-learning-go-client/mlapi"
 )
@@ -24,6 +28,7 @@ A job defines the queries and model parameters for a machine learning task.
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: resourceMachineLearningJobCustomizeDiff,
 
 		Schema: map[string]*schema.Schema{
 			"id": {
@@ -47,9 +52,17 @@ A job defines the queries and model parameters for a machine learning task.
 				Optional:    true,
 			},
 			"datasource_id": {
-				Description: "The id of the datasource to query.",
-				Type:        schema.TypeInt,
-				Required:    true,
+				Description:  "The id of the datasource to query. Either this or `datasource_uid` must be set.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"datasource_id", "datasource_uid"},
+			},
+			"datasource_uid": {
+				Description: "The uid of the datasource to query. Either this or `datasource_id` must be set.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
 			},
 			"datasource_type": {
 				Description: "The type of datasource being queried. Currently allowed values are prometheus, graphite, loki, postgres, and datadog.",
@@ -79,6 +92,27 @@ A job defines the queries and model parameters for a machine learning task.
 				Optional:    true,
 				Default:     int(90 * 24 * time.Hour / time.Second),
 			},
+			"training_frequency": {
+				Description:  "How often, in seconds, the model is retrained.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      int(24 * time.Hour / time.Second),
+				ValidateFunc: validation.IntAtLeast(int(time.Hour / time.Second)),
+			},
+			"algorithm": {
+				Description: "The algorithm used to train the model. It is validated against the algorithms supported by the ML plugin when planning. See https://grafana.com/docs/grafana-cloud/machine-learning/models/ for the available algorithms.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "Prophet",
+			},
+			"holidays": {
+				Description: "IDs of the holidays to exclude from the training data. See `grafana_machine_learning_holiday`.",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 		},
 	}
 }
@@ -91,6 +125,11 @@ func resourceMachineLearningJobCreate(ctx context.Context, d *schema.ResourceDat
 		return diag.FromErr(err)
 	}
 	d.SetId(job.ID)
+	if holidays := setToStringSlice(d.Get("holidays").(*schema.Set)); len(holidays) > 0 {
+		if err := c.LinkHolidaysToJob(ctx, job.ID, holidays); err != nil {
+			return diag.FromErr(err)
+		}
+	}
 	return resourceMachineLearningJobRead(ctx, d, meta)
 }
 
@@ -105,11 +144,15 @@ func resourceMachineLearningJobRead(ctx context.Context, d *schema.ResourceData,
 	d.Set("metric", job.Metric)
 	d.Set("description", job.Description)
 	d.Set("datasource_id", job.DatasourceID)
+	d.Set("datasource_uid", job.DatasourceUID)
 	d.Set("datasource_type", job.DatasourceType)
 	d.Set("query_params", job.QueryParams)
 	d.Set("interval", job.Interval)
 	d.Set("hyper_params", job.HyperParams)
 	d.Set("training_window", job.TrainingWindow)
+	d.Set("training_frequency", job.TrainingFrequency)
+	d.Set("algorithm", job.Algorithm)
+	d.Set("holidays", job.Holidays)
 
 	return nil
 }
@@ -121,6 +164,11 @@ func resourceMachineLearningJobUpdate(ctx context.Context, d *schema.ResourceDat
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	if d.HasChange("holidays") {
+		if err := c.LinkHolidaysToJob(ctx, d.Id(), setToStringSlice(d.Get("holidays").(*schema.Set))); err != nil {
+			return diag.FromErr(err)
+		}
+	}
 	return resourceMachineLearningJobRead(ctx, d, meta)
 }
 
@@ -134,20 +182,49 @@ func resourceMachineLearningJobDelete(ctx context.Context, d *schema.ResourceDat
 	return nil
 }
 
-func makeMLJob(d *schema.ResourceData, meta interface{}) mlapi.Job {
-	return mlapi.Job{
-		ID:                d.Id(),
-		Name:              d.Get("name").(string),
-		Metric:            d.Get("metric").(string),
-		Description:       d.Get("description").(string),
-		GrafanaURL:        meta.(*client).gapiURL,
-		DatasourceID:      uint(d.Get("datasource_id").(int)),
-		DatasourceType:    d.Get("datasource_type").(string),
-		QueryParams:       d.Get("query_params").(map[string]interface{}),
-		Interval:          uint(d.Get("interval").(int)),
-		Algorithm:         "Prophet",
-		HyperParams:       d.Get("hyper_params").(map[string]interface{}),
-		TrainingWindow:    uint(d.Get("training_window").(int)),
-		TrainingFrequency: uint(24 * time.Hour / time.Second),
+// resourceMachineLearningJobCustomizeDiff validates the algorithm against the ones supported by the ML plugin.
+func resourceMachineLearningJobCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	c, ok := meta.(*client)
+	if !ok || c.mlapi == nil || !diff.NewValueKnown("algorithm") || (diff.Id() != "" && !diff.HasChange("algorithm")) {
+		return nil
+	}
+	algorithms, err := c.mlapi.JobAlgorithms(ctx)
+	if err != nil || len(algorithms) == 0 {
+		// The algorithm will be validated by the ML API on apply
+		log.Printf("[WARN] could not list the ML algorithms to validate the job: %v", err)
+		return nil
+	}
+	algorithm := diff.Get("algorithm").(string)
+	for _, a := range algorithms {
+		if a == algorithm {
+			return nil
+		}
+	}
+	return cty.GetAttrPath("algorithm").NewErrorf("algorithm: %q is not supported by the ML plugin, expected one of %s", algorithm, strings.Join(algorithms, ", "))
+}
+
+func makeMLJob(d *schema.ResourceData, meta interface{}) mlJob {
+	job := mlJob{
+		Job: mlapi.Job{
+			ID:                d.Id(),
+			Name:              d.Get("name").(string),
+			Metric:            d.Get("metric").(string),
+			Description:       d.Get("description").(string),
+			GrafanaURL:        meta.(*client).gapiURL,
+			DatasourceType:    d.Get("datasource_type").(string),
+			QueryParams:       d.Get("query_params").(map[string]interface{}),
+			Interval:          uint(d.Get("interval").(int)),
+			Algorithm:         d.Get("algorithm").(string),
+			HyperParams:       d.Get("hyper_params").(map[string]interface{}),
+			TrainingWindow:    uint(d.Get("training_window").(int)),
+			TrainingFrequency: uint(d.Get("training_frequency").(int)),
+		},
+	}
+	// Only send the datasource reference that is configured, the other one is computed by the API
+	if config := d.GetRawConfig(); !config.IsNull() && !config.GetAttr("datasource_uid").IsNull() {
+		job.DatasourceUID = d.Get("datasource_uid").(string)
+	} else {
+		job.DatasourceID = uint(d.Get("datasource_id").(int))
 	}
+	return job
 }
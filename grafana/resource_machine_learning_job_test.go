@@ -2,19 +2,25 @@ package grafana
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
 	"regexp"
+	"sort"
 	"testing"
 
-	"github.com/grafana/machine-learning-go-client/mlapi"
+	gapi "github.com/grafana/grafana-api-golang-client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccResourceMachineLearningJob(t *testing.T) {
 	CheckCloudInstanceTestsEnabled(t)
 
-	var job mlapi.Job
+	var job mlJob
 	resource.Test(t, resource.TestCase{
 		ProviderFactories: testAccProviderFactories,
 		CheckDestroy:      testAccMLJobCheckDestroy(&job),
@@ -31,6 +37,8 @@ func TestAccResourceMachineLearningJob(t *testing.T) {
 					resource.TestCheckResourceAttr("grafana_machine_learning_job.test_job", "query_params.expr", "grafanacloud_grafana_instance_active_user_count"),
 					resource.TestCheckResourceAttr("grafana_machine_learning_job.test_job", "interval", "300"),
 					resource.TestCheckResourceAttr("grafana_machine_learning_job.test_job", "training_window", "7776000"),
+					resource.TestCheckResourceAttr("grafana_machine_learning_job.test_job", "training_frequency", "86400"),
+					resource.TestCheckResourceAttr("grafana_machine_learning_job.test_job", "algorithm", "Prophet"),
 				),
 			},
 			{
@@ -48,11 +56,25 @@ func TestAccResourceMachineLearningJob(t *testing.T) {
 					resource.TestCheckResourceAttr("grafana_machine_learning_job.test_job", "hyper_params.weekly_seasonality", "10"),
 				),
 			},
+			{
+				Config: testAccExample(t, "resources/grafana_machine_learning_job/datasource_uid_job.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("grafana_machine_learning_job.test_job", "id"),
+					resource.TestCheckResourceAttr("grafana_machine_learning_job.test_job", "datasource_uid", "grafanacloud-prom"),
+					resource.TestCheckResourceAttr("grafana_machine_learning_job.test_job", "algorithm", "Prophet"),
+					resource.TestCheckResourceAttr("grafana_machine_learning_job.test_job", "training_frequency", "43200"),
+				),
+			},
+			{
+				ResourceName:      "grafana_machine_learning_job.test_job",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
 
-func testAccMLJobCheckExists(rn string, job *mlapi.Job) resource.TestCheckFunc {
+func testAccMLJobCheckExists(rn string, job *mlJob) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[rn]
 		if !ok {
@@ -75,7 +97,7 @@ func testAccMLJobCheckExists(rn string, job *mlapi.Job) resource.TestCheckFunc {
 	}
 }
 
-func testAccMLJobCheckDestroy(job *mlapi.Job) resource.TestCheckFunc {
+func testAccMLJobCheckDestroy(job *mlJob) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		// This check is to make sure that no pointer conversions are incorrect
 		// while mutating job.
@@ -116,3 +138,144 @@ func TestAccResourceInvalidMachineLearningJob(t *testing.T) {
 		},
 	})
 }
+
+func TestMLJobDatasourceUnit(t *testing.T) {
+	IsUnitTest(t)
+
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/plugins/grafana-ml-app/resources/manage/api/v1/jobs" {
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+		if auth := r.Header.Get("Authorization"); auth != "Bearer token" {
+			t.Errorf("unexpected authorization header %q", auth)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		fmt.Fprint(w, `{"status": "success", "data": {"id": "1", "datasourceId": 2, "datasourceUid": "prom"}}`)
+	}))
+	defer server.Close()
+
+	c, err := createMLClient(server.URL, &gapi.Config{APIKey: "token"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	job, err := c.NewJob(context.Background(), mlJob{DatasourceUID: "prom"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := body["datasourceId"]; ok {
+		t.Errorf("expected the datasource ID not to be sent, got %v", body["datasourceId"])
+	}
+	if body["datasourceUid"] != "prom" {
+		t.Errorf("expected the datasource UID to be sent, got %v", body["datasourceUid"])
+	}
+	if job.ID != "1" || job.DatasourceID != 2 || job.DatasourceUID != "prom" {
+		t.Errorf("unexpected job: %+v", job)
+	}
+}
+
+func TestMLJobAlgorithmUnit(t *testing.T) {
+	IsUnitTest(t)
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/plugins/grafana-ml-app/resources/manage/api/v1/algorithms" {
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+		requests++
+		fmt.Fprint(w, `{"status": "success", "data": [{"name": "Prophet"}, {"name": "Seasonal"}]}`)
+	}))
+	defer server.Close()
+
+	ml, err := createMLClient(server.URL, &gapi.Config{APIKey: "token"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	meta := &client{gapiURL: server.URL, mlapi: ml}
+
+	// Value of the attributes that are unknown until apply, such as references to other resources
+	const unknown = "74D93920-ED26-11E3-AC10-0800200C9A66"
+	for algorithm, errRegexp := range map[string]string{
+		"Prophet":  "",
+		"Seasonal": "",
+		unknown:    "",
+		"prophet":  `algorithm: "prophet" is not supported by the ML plugin, expected one of Prophet, Seasonal`,
+	} {
+		config := terraform.NewResourceConfigRaw(map[string]interface{}{
+			"name":            "job",
+			"metric":          "metric",
+			"datasource_uid":  "prom",
+			"datasource_type": "prometheus",
+			"query_params":    map[string]interface{}{"expr": "up"},
+			"algorithm":       algorithm,
+		})
+		_, err := ResourceMachineLearningJob().Diff(context.Background(), nil, config, meta)
+		switch {
+		case errRegexp == "" && err != nil:
+			t.Errorf("%s: unexpected error: %v", algorithm, err)
+		case errRegexp != "" && (err == nil || !regexp.MustCompile(errRegexp).MatchString(err.Error())):
+			t.Errorf("%s: expected an error matching %q, got %v", algorithm, errRegexp, err)
+		}
+	}
+
+	if requests != 1 {
+		t.Errorf("expected the algorithms to be fetched once, got %d requests", requests)
+	}
+}
+
+func TestMLJobHolidaysUnit(t *testing.T) {
+	IsUnitTest(t)
+
+	for name, tc := range map[string]struct {
+		holidays []interface{}
+		// The holidays aren't returned in the order they were linked
+		response string
+		links    int
+	}{
+		"no holidays":   {holidays: []interface{}{}, response: `[]`, links: 0},
+		"with holidays": {holidays: []interface{}{"a", "b"}, response: `["b", "a"]`, links: 1},
+	} {
+		links := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/api/plugins/grafana-ml-app/resources/manage/api/v1/jobs":
+				fmt.Fprint(w, `{"status": "success", "data": {"id": "1"}}`)
+			case "/api/plugins/grafana-ml-app/resources/manage/api/v1/jobs/1":
+				fmt.Fprintf(w, `{"status": "success", "data": {"id": "1", "holidays": %s}}`, tc.response)
+			case "/api/plugins/grafana-ml-app/resources/manage/api/v1/jobs/1/holidays":
+				links++
+			default:
+				t.Errorf("unexpected request to %s", r.URL.Path)
+			}
+		}))
+
+		ml, err := createMLClient(server.URL, &gapi.Config{APIKey: "token"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		d := schema.TestResourceDataRaw(t, ResourceMachineLearningJob().Schema, map[string]interface{}{
+			"name":            "job",
+			"metric":          "metric",
+			"datasource_id":   1,
+			"datasource_type": "prometheus",
+			"query_params":    map[string]interface{}{"expr": "up"},
+			"holidays":        tc.holidays,
+		})
+		if diags := resourceMachineLearningJobCreate(context.Background(), d, &client{gapiURL: server.URL, mlapi: ml}); diags.HasError() {
+			t.Fatalf("%s: %v", name, diags)
+		}
+		server.Close()
+
+		if links != tc.links {
+			t.Errorf("%s: expected %d holiday link requests, got %d", name, tc.links, links)
+		}
+		got := setToStringSlice(d.Get("holidays").(*schema.Set))
+		sort.Strings(got)
+		if expected := listToStringSlice(tc.holidays); !reflect.DeepEqual(got, expected) {
+			t.Errorf("%s: expected holidays %v, got %v", name, expected, got)
+		}
+	}
+}
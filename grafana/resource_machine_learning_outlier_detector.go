@@ -0,0 +1,221 @@
+package grafana
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func ResourceMachineLearningOutlierDetector() *schema.Resource {
+	return &schema.Resource{
+
+		Description: `
+An outlier detector monitors the results of a query and reports when its values are outside normal bands.
+
+The normal band is configured by choice of algorithm, its sensitivity and other configuration.
+
+Visit https://grafana.com/docs/grafana-cloud/machine-learning/outlier-detection/ for more details.
+`,
+
+		CreateContext: resourceMachineLearningOutlierDetectorCreate,
+		ReadContext:   resourceMachineLearningOutlierDetectorRead,
+		UpdateContext: resourceMachineLearningOutlierDetectorUpdate,
+		DeleteContext: resourceMachineLearningOutlierDetectorDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Description: "The ID of the outlier detector.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"name": {
+				Description: "The name of the outlier detector.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"metric": {
+				Description: "The metric used to query the outlier detector results.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"description": {
+				Description: "A description of the outlier detector.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"datasource_id": {
+				Description:  "The id of the datasource to query. Either this or `datasource_uid` must be set.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"datasource_id", "datasource_uid"},
+			},
+			"datasource_uid": {
+				Description: "The uid of the datasource to query. Either this or `datasource_id` must be set.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+			"datasource_type": {
+				Description:  "The type of datasource being queried. Currently allowed values are prometheus, graphite, loki, postgres, and datadog.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"prometheus", "graphite", "loki", "postgres", "datadog"}, false),
+			},
+			"query_params": {
+				Description: "An object representing the query params to query Grafana with.",
+				Type:        schema.TypeMap,
+				Required:    true,
+			},
+			"interval": {
+				Description: "The data interval in seconds to monitor.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     300,
+			},
+			"algorithm": {
+				Description: "The algorithm to use and its configuration. See https://grafana.com/docs/grafana-cloud/machine-learning/outlier-detection/ for details.",
+				Type:        schema.TypeList,
+				Required:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Description:  "The name of the algorithm to use. One of `" + strings.Join(mlOutlierDetectorAlgorithms, "`, `") + "`.",
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice(mlOutlierDetectorAlgorithms, false),
+						},
+						"sensitivity": {
+							Description:  "Specify the sensitivity of the detector (in range [0,1]).",
+							Type:         schema.TypeFloat,
+							Required:     true,
+							ValidateFunc: validation.FloatBetween(0, 1),
+						},
+						"config": {
+							Description: "For DBSCAN only, specify the configuration map.",
+							Type:        schema.TypeList,
+							Optional:    true,
+							MaxItems:    1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"epsilon": {
+										Description:  "Specify the epsilon parameter (positive float).",
+										Type:         schema.TypeFloat,
+										Required:     true,
+										ValidateFunc: validation.FloatAtLeast(0),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceMachineLearningOutlierDetectorCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*client).mlapi
+	outlier := makeMLOutlierDetector(d, meta)
+	outlier, err := c.NewOutlierDetector(ctx, outlier)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(outlier.ID)
+	return resourceMachineLearningOutlierDetectorRead(ctx, d, meta)
+}
+
+func resourceMachineLearningOutlierDetectorRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*client).mlapi
+	outlier, err := c.OutlierDetector(ctx, d.Id())
+	if err != nil {
+		if strings.Contains(err.Error(), "status: 404") {
+			log.Printf("[WARN] removing outlier detector %s from state because it no longer exists in grafana", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	algorithm := map[string]interface{}{
+		"name":        outlier.Algorithm.Name,
+		"sensitivity": outlier.Algorithm.Sensitivity,
+		"config":      []interface{}{},
+	}
+	if outlier.Algorithm.Config != nil {
+		algorithm["config"] = []interface{}{
+			map[string]interface{}{
+				"epsilon": outlier.Algorithm.Config.Epsilon,
+			},
+		}
+	}
+
+	d.Set("name", outlier.Name)
+	d.Set("metric", outlier.Metric)
+	d.Set("description", outlier.Description)
+	d.Set("datasource_id", outlier.DatasourceID)
+	d.Set("datasource_uid", outlier.DatasourceUID)
+	d.Set("datasource_type", outlier.DatasourceType)
+	d.Set("query_params", outlier.QueryParams)
+	d.Set("interval", outlier.Interval)
+	d.Set("algorithm", []interface{}{algorithm})
+
+	return nil
+}
+
+func resourceMachineLearningOutlierDetectorUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*client).mlapi
+	outlier := makeMLOutlierDetector(d, meta)
+	if _, err := c.UpdateOutlierDetector(ctx, outlier); err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceMachineLearningOutlierDetectorRead(ctx, d, meta)
+}
+
+func resourceMachineLearningOutlierDetectorDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*client).mlapi
+	if err := c.DeleteOutlierDetector(ctx, d.Id()); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId("")
+	return nil
+}
+
+func makeMLOutlierDetector(d *schema.ResourceData, meta interface{}) mlOutlierDetector {
+	outlier := mlOutlierDetector{
+		ID:             d.Id(),
+		Name:           d.Get("name").(string),
+		Metric:         d.Get("metric").(string),
+		Description:    d.Get("description").(string),
+		GrafanaURL:     meta.(*client).gapiURL,
+		DatasourceType: d.Get("datasource_type").(string),
+		QueryParams:    d.Get("query_params").(map[string]interface{}),
+		Interval:       uint(d.Get("interval").(int)),
+	}
+	// Only send the datasource reference that is configured, the other one is computed by the API
+	if config := d.GetRawConfig(); !config.IsNull() && !config.GetAttr("datasource_uid").IsNull() {
+		outlier.DatasourceUID = d.Get("datasource_uid").(string)
+	} else {
+		outlier.DatasourceID = uint(d.Get("datasource_id").(int))
+	}
+
+	algorithm := d.Get("algorithm").([]interface{})[0].(map[string]interface{})
+	outlier.Algorithm = mlOutlierDetectorAlgorithm{
+		Name:        algorithm["name"].(string),
+		Sensitivity: algorithm["sensitivity"].(float64),
+	}
+	if config := algorithm["config"].([]interface{}); len(config) > 0 && config[0] != nil {
+		outlier.Algorithm.Config = &mlOutlierDetectorAlgorithmConfig{
+			Epsilon: config[0].(map[string]interface{})["epsilon"].(float64),
+		}
+	}
+	return outlier
+}
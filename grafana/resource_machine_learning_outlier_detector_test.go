@@ -0,0 +1,120 @@
+package grafana
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResourceMachineLearningOutlierDetector(t *testing.T) {
+	CheckCloudInstanceTestsEnabled(t)
+
+	var outlier mlOutlierDetector
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccMLOutlierDetectorCheckDestroy(&outlier),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExample(t, "resources/grafana_machine_learning_outlier_detector/resource.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccMLOutlierDetectorCheckExists("grafana_machine_learning_outlier_detector.test_outlier_detector", &outlier),
+					resource.TestCheckResourceAttrSet("grafana_machine_learning_outlier_detector.test_outlier_detector", "id"),
+					resource.TestCheckResourceAttr("grafana_machine_learning_outlier_detector.test_outlier_detector", "name", "Test Outlier Detector"),
+					resource.TestCheckResourceAttr("grafana_machine_learning_outlier_detector.test_outlier_detector", "metric", "tf_test_mad_job"),
+					resource.TestCheckResourceAttr("grafana_machine_learning_outlier_detector.test_outlier_detector", "datasource_uid", "grafanacloud-prom"),
+					resource.TestCheckResourceAttr("grafana_machine_learning_outlier_detector.test_outlier_detector", "query_params.expr", "grafanacloud_grafana_instance_active_user_count"),
+					resource.TestCheckResourceAttr("grafana_machine_learning_outlier_detector.test_outlier_detector", "interval", "300"),
+					resource.TestCheckResourceAttr("grafana_machine_learning_outlier_detector.test_outlier_detector", "algorithm.0.name", "mad"),
+					resource.TestCheckResourceAttr("grafana_machine_learning_outlier_detector.test_outlier_detector", "algorithm.0.sensitivity", "0.7"),
+					resource.TestCheckResourceAttr("grafana_machine_learning_outlier_detector.test_outlier_detector", "algorithm.0.config.#", "0"),
+				),
+			},
+			{
+				Config: testAccExample(t, "resources/grafana_machine_learning_outlier_detector/dbscan.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccMLOutlierDetectorCheckExists("grafana_machine_learning_outlier_detector.test_outlier_detector", &outlier),
+					resource.TestCheckResourceAttr("grafana_machine_learning_outlier_detector.test_outlier_detector", "metric", "tf_test_dbscan_job"),
+					resource.TestCheckResourceAttr("grafana_machine_learning_outlier_detector.test_outlier_detector", "algorithm.0.name", "dbscan"),
+					resource.TestCheckResourceAttr("grafana_machine_learning_outlier_detector.test_outlier_detector", "algorithm.0.sensitivity", "0.5"),
+					resource.TestCheckResourceAttr("grafana_machine_learning_outlier_detector.test_outlier_detector", "algorithm.0.config.0.epsilon", "1"),
+				),
+			},
+			{
+				ResourceName:      "grafana_machine_learning_outlier_detector.test_outlier_detector",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+const machineLearningOutlierDetectorInvalid = `
+resource "grafana_machine_learning_outlier_detector" "invalid" {
+  name            = "Test Outlier Detector"
+  metric          = "tf_test_invalid_job"
+  datasource_type = "prometheus"
+  datasource_uid  = "grafanacloud-prom"
+  query_params = {
+    expr = "grafanacloud_grafana_instance_active_user_count"
+  }
+  algorithm {
+    name        = "dbscan"
+    sensitivity = 1.5
+  }
+}
+`
+
+func TestAccResourceInvalidMachineLearningOutlierDetector(t *testing.T) {
+	CheckCloudInstanceTestsEnabled(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      machineLearningOutlierDetectorInvalid,
+				ExpectError: regexp.MustCompile(".*sensitivity.*"),
+			},
+		},
+	})
+}
+
+func testAccMLOutlierDetectorCheckExists(rn string, outlier *mlOutlierDetector) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[rn]
+		if !ok {
+			return fmt.Errorf("resource not found: %s\n %#v", rn, s.RootModule().Resources)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("resource id not set")
+		}
+
+		client := testAccProvider.Meta().(*client).mlapi
+		gotOutlier, err := client.OutlierDetector(context.Background(), rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("error getting outlier detector: %s", err)
+		}
+
+		*outlier = gotOutlier
+
+		return nil
+	}
+}
+
+func testAccMLOutlierDetectorCheckDestroy(outlier *mlOutlierDetector) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if outlier.ID == "" {
+			return fmt.Errorf("checking deletion of empty id")
+		}
+		client := testAccProvider.Meta().(*client).mlapi
+		_, err := client.OutlierDetector(context.Background(), outlier.ID)
+		if err == nil {
+			return fmt.Errorf("outlier detector still exists on server")
+		}
+		return nil
+	}
+}
@@ -45,7 +45,7 @@ func ResourceOrganization() *schema.Resource {
 		UpdateContext: UpdateOrganization,
 		DeleteContext: DeleteOrganization,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importStateWithDeleteProtection,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -120,6 +120,7 @@ access to the organization. Note: users specified here must already exist in
 Grafana unless 'create_users' is set to true.
 `,
 			},
+			"delete_protection": deleteProtectionSchema("organization"),
 		},
 	}
 }
@@ -182,6 +183,9 @@ func UpdateOrganization(ctx context.Context, d *schema.ResourceData, meta interf
 func DeleteOrganization(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*client).gapi
 	orgID, _ := strconv.ParseInt(d.Id(), 10, 64)
+	if diag := checkDeleteProtection(d, "organization", d.Get("name").(string)); diag != nil {
+		return diag
+	}
 	if err := client.DeleteOrg(orgID); err != nil {
 		return diag.FromErr(err)
 	}
@@ -0,0 +1,75 @@
+package grafana
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func ResourceOrganizationPreferences() *schema.Resource {
+	return &schema.Resource{
+
+		Description: `
+Manages the preferences of the organization the provider is configured for.
+Only one ` + "`grafana_organization_preferences`" + ` resource should be defined per organization.
+Destroying the resource resets the preferences to their defaults.
+
+* [Official documentation](https://grafana.com/docs/grafana/latest/administration/preferences/)
+* [HTTP API](https://grafana.com/docs/grafana/latest/http_api/preferences/)
+`,
+
+		CreateContext: UpdateOrganizationPreferences,
+		ReadContext:   ReadOrganizationPreferences,
+		UpdateContext: UpdateOrganizationPreferences,
+		DeleteContext: DeleteOrganizationPreferences,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: preferencesSchema("organization", "member of the organization"),
+	}
+}
+
+func UpdateOrganizationPreferences(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*client)
+
+	if err := client.updatePreferences(ctx, "/api/org/preferences", expandPreferences(d)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return ReadOrganizationPreferences(ctx, d, meta)
+}
+
+func ReadOrganizationPreferences(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*client)
+
+	var org struct {
+		ID int64 `json:"id"`
+	}
+	if err := client.grafanaAPIRequest(ctx, http.MethodGet, "/api/org", nil, &org); err != nil {
+		return diag.FromErr(err)
+	}
+	preferences, err := client.getPreferences(ctx, "/api/org/preferences")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(strconv.FormatInt(org.ID, 10))
+	flattenPreferences(d, preferences)
+
+	return nil
+}
+
+func DeleteOrganizationPreferences(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// There is no delete call for organization preferences, they are reset to the defaults instead.
+	client := meta.(*client)
+
+	if err := client.updatePreferences(ctx, "/api/org/preferences", preferences{}); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,34 @@
+package grafana
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccOrganizationPreferences_basic(t *testing.T) {
+	CheckOSSTestsEnabled(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExample(t, "resources/grafana_organization_preferences/resource.tf"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("grafana_organization_preferences.preferences", "id"),
+					resource.TestCheckResourceAttr("grafana_organization_preferences.preferences", "theme", "light"),
+					resource.TestCheckResourceAttr("grafana_organization_preferences.preferences", "timezone", "utc"),
+					resource.TestCheckResourceAttr("grafana_organization_preferences.preferences", "week_start", "sunday"),
+					resource.TestCheckResourceAttr("grafana_organization_preferences.preferences", "locale", "en-US"),
+					resource.TestCheckResourceAttr("grafana_organization_preferences.preferences", "query_history_home_tab", "starred"),
+					resource.TestCheckResourceAttr("grafana_organization_preferences.preferences", "home_dashboard_uid", "org-home"),
+				),
+			},
+			{
+				ResourceName:      "grafana_organization_preferences.preferences",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
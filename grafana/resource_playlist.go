@@ -8,8 +8,10 @@ import (
 	"strings"
 
 	gapi "github.com/grafana/grafana-api-golang-client"
+	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func ResourcePlaylist() *schema.Resource {
@@ -21,6 +23,7 @@ func ResourcePlaylist() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: validatePlaylistItems,
 
 		Description: `
 		* [Official documentation](https://grafana.com/docs/grafana/latest/dashboards/playlist/)
@@ -28,6 +31,11 @@ func ResourcePlaylist() *schema.Resource {
 		`,
 
 		Schema: map[string]*schema.Schema{
+			"uid": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The unique identifier of the playlist. Empty for Grafana versions that identify playlists by numeric ID.",
+			},
 			"name": {
 				Type:        schema.TypeString,
 				Required:    true,
@@ -38,6 +46,69 @@ func ResourcePlaylist() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+			"item": {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"order": {
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+						"title": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Description: "The type of the item. `dashboard_by_uid` adds the dashboard whose UID is `value`, " +
+								"`dashboard_by_tag` adds all dashboards with the tag `value`. `dashboard_by_id` is deprecated, use `dashboard_by_uid` instead.",
+							ValidateFunc: validation.StringInSlice(playlistItemTypes, false),
+						},
+						"value": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The value of the item, depending on its `type`. Required when `type` is set.",
+						},
+					},
+				},
+			},
+			"org_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Type:    resourcePlaylistV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourcePlaylistStateUpgradeV0,
+				Version: 0,
+			},
+		},
+	}
+}
+
+// resourcePlaylistV0 is the original schema for this resource, which was
+// identified by the numeric ID of the playlist. In Grafana 9, playlists are
+// identified by UID and the numeric ID endpoints were removed.
+func resourcePlaylistV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"interval": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
 			"item": {
 				Type:     schema.TypeSet,
 				Required: true,
@@ -74,45 +145,58 @@ func ResourcePlaylist() *schema.Resource {
 	}
 }
 
-func CreatePlaylist(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*client).gapi
-
-	playlist := gapi.Playlist{
-		Name:     d.Get("name").(string),
-		Interval: d.Get("interval").(string),
-		Items:    expandPlaylistItems(d.Get("item").(*schema.Set).List()),
+// resourcePlaylistStateUpgradeV0 migrates from version 0 of this resource's
+// schema to version 1.
+// * Use UID instead of the numeric ID, if the Grafana version supports it.
+func resourcePlaylistStateUpgradeV0(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	client := meta.(*client)
+	id, err := strconv.Atoi(rawState["id"].(string))
+	if err != nil {
+		// Already migrated
+		return rawState, nil
+	}
+	playlists, err := client.listPlaylists(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error attempting to migrate state. Grafana returned an error while listing playlists: %s", err)
+	}
+	for _, p := range playlists {
+		if p.ID == id {
+			rawState["id"] = p.identifier()
+			rawState["uid"] = p.UID
+			return rawState, nil
+		}
 	}
+	// Playlist does not exist. Let Terraform recreate it.
+	return rawState, nil
+}
 
-	id, err := client.NewPlaylist(playlist)
+func CreatePlaylist(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*client)
 
+	resp, err := client.newPlaylist(ctx, makePlaylist(d))
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("error creating Playlist: %w", err))
 	}
 
-	d.SetId(strconv.Itoa(id))
+	d.SetId(resp.identifier())
 
 	return ReadPlaylist(ctx, d, meta)
 }
 
 func ReadPlaylist(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*client).gapi
-
-	id, err := strconv.Atoi(d.Id())
-	if err != nil {
-		return diag.FromErr(fmt.Errorf("error reading Playlist (%s): %w", d.Id(), err))
-	}
-
-	resp, err := client.Playlist(id)
+	client := meta.(*client)
 
+	resp, err := client.getPlaylist(ctx, d.Id())
 	if err != nil {
 		if strings.HasPrefix(err.Error(), "status: 404") {
-			log.Printf("[WARN] removing playlist %d from state because it no longer exists in grafana", id)
+			log.Printf("[WARN] removing playlist %s from state because it no longer exists in grafana", d.Id())
 			d.SetId("")
 			return nil
 		}
 		return diag.FromErr(fmt.Errorf("error reading Playlist (%s): %w", d.Id(), err))
 	}
 
+	d.Set("uid", resp.UID)
 	d.Set("name", resp.Name)
 	d.Set("interval", resp.Interval)
 	if err := d.Set("item", flattenPlaylistItems(resp.Items)); err != nil {
@@ -123,16 +207,9 @@ func ReadPlaylist(ctx context.Context, d *schema.ResourceData, meta interface{})
 }
 
 func UpdatePlaylist(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*client).gapi
+	client := meta.(*client)
 
-	playlist := gapi.Playlist{
-		Name:     d.Get("name").(string),
-		Interval: d.Get("interval").(string),
-		Items:    expandPlaylistItems(d.Get("item").(*schema.Set).List()),
-	}
-
-	err := client.UpdatePlaylist(playlist)
-	if err != nil {
+	if err := client.updatePlaylist(ctx, d.Id(), makePlaylist(d)); err != nil {
 		return diag.FromErr(fmt.Errorf("error updating Playlist (%s): %w", d.Id(), err))
 	}
 
@@ -140,15 +217,9 @@ func UpdatePlaylist(ctx context.Context, d *schema.ResourceData, meta interface{
 }
 
 func DeletePlaylist(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*client).gapi
-
-	id, err := strconv.Atoi(d.Id())
-
-	if err != nil {
-		return diag.FromErr(fmt.Errorf("error deleting Playlist (%s): %w", d.Id(), err))
-	}
+	client := meta.(*client)
 
-	if err := client.DeletePlaylist(id); err != nil {
+	if err := client.deletePlaylist(ctx, d.Id()); err != nil {
 		if strings.HasPrefix(err.Error(), "status: 404") {
 			return nil
 		}
@@ -158,6 +229,38 @@ func DeletePlaylist(ctx context.Context, d *schema.ResourceData, meta interface{
 	return nil
 }
 
+func makePlaylist(d *schema.ResourceData) playlist {
+	return playlist{
+		Playlist: gapi.Playlist{
+			Name:     d.Get("name").(string),
+			Interval: d.Get("interval").(string),
+			Items:    expandPlaylistItems(d.Get("item").(*schema.Set).List()),
+		},
+		UID: d.Get("uid").(string),
+	}
+}
+
+// validatePlaylistItems checks that typed items have a value. Typed items without a value would show no dashboard.
+func validatePlaylistItems(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.GetAttr("item").IsKnown() || config.GetAttr("item").IsNull() {
+		return nil
+	}
+	var errs *multierror.Error
+	for it := config.GetAttr("item").ElementIterator(); it.Next(); {
+		_, item := it.Element()
+		itemType, value := item.GetAttr("type"), item.GetAttr("value")
+		// Values that are unknown until apply can't be checked
+		if !itemType.IsKnown() || itemType.IsNull() || !value.IsKnown() {
+			continue
+		}
+		if value.IsNull() || value.AsString() == "" {
+			errs = multierror.Append(errs, fmt.Errorf("value must be set for playlist items of type %s", itemType.AsString()))
+		}
+	}
+	return errs.ErrorOrNil()
+}
+
 func expandPlaylistItems(items []interface{}) []gapi.PlaylistItem {
 	playlistItems := make([]gapi.PlaylistItem, 0)
 	for _, item := range items {
@@ -1,12 +1,15 @@
 package grafana
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"regexp"
-	"strconv"
 	"strings"
 	"testing"
 
+	gapi "github.com/grafana/grafana-api-golang-client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -27,7 +30,7 @@ func TestAccPlaylist_basic(t *testing.T) {
 				Config: testAccPlaylistConfigBasic(rName),
 				Check: resource.ComposeTestCheckFunc(
 					testAccPlaylistCheckExists(),
-					resource.TestMatchResourceAttr(paylistResource, "id", regexp.MustCompile(`\d+`)),
+					resource.TestCheckResourceAttrSet(paylistResource, "id"),
 					resource.TestCheckResourceAttr(paylistResource, "name", rName),
 					resource.TestCheckResourceAttr(paylistResource, "item.#", "2"),
 					resource.TestCheckTypeSetElemNestedAttrs(paylistResource, "item.*", map[string]string{
@@ -69,7 +72,7 @@ func TestAccPlaylist_update(t *testing.T) {
 				Config: testAccPlaylistConfigUpdate(updatedName),
 				Check: resource.ComposeTestCheckFunc(
 					testAccPlaylistCheckExists(),
-					resource.TestMatchResourceAttr(paylistResource, "id", regexp.MustCompile(`\d+`)),
+					resource.TestCheckResourceAttrSet(paylistResource, "id"),
 					resource.TestCheckResourceAttr(paylistResource, "name", updatedName),
 					resource.TestCheckResourceAttr(paylistResource, "item.#", "1"),
 					resource.TestCheckTypeSetElemNestedAttrs(paylistResource, "item.*", map[string]string{
@@ -89,6 +92,66 @@ func TestAccPlaylist_update(t *testing.T) {
 	})
 }
 
+func TestAccPlaylist_typedItems(t *testing.T) {
+	CheckOSSTestsEnabled(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccPlaylistDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExample(t, "resources/grafana_playlist/resource.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccPlaylistCheckExists(),
+					resource.TestCheckResourceAttrSet(paylistResource, "uid"),
+					resource.TestCheckResourceAttrPair(paylistResource, "id", paylistResource, "uid"),
+					resource.TestCheckResourceAttr(paylistResource, "item.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs(paylistResource, "item.*", map[string]string{
+						"order": "1",
+						"type":  "dashboard_by_tag",
+						"value": "terraform",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs(paylistResource, "item.*", map[string]string{
+						"order": "2",
+						"type":  "dashboard_by_uid",
+						"value": "playlist-dashboard",
+					}),
+				),
+			},
+			{
+				ResourceName:      paylistResource,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccPlaylist_invalidItem(t *testing.T) {
+	CheckOSSTestsEnabled(t)
+
+	resource.ParallelTest(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "grafana_playlist" "test" {
+	name     = "invalid"
+	interval = "5m"
+
+	item {
+		order = 1
+		title = "Dashboards By Tag"
+		type  = "dashboard_by_tag"
+	}
+}
+`,
+				ExpectError: regexp.MustCompile("value must be set for playlist items of type dashboard_by_tag"),
+			},
+		},
+	})
+}
+
 func TestAccPlaylist_disappears(t *testing.T) {
 	CheckOSSTestsEnabled(t)
 
@@ -121,13 +184,8 @@ func testAccPlaylistCheckExists() resource.TestCheckFunc {
 			return fmt.Errorf("resource id not set")
 		}
 
-		client := testAccProvider.Meta().(*client).gapi
-		id, err := strconv.Atoi(rs.Primary.ID)
-		if err != nil {
-			return err
-		}
-
-		_, err = client.Playlist(id)
+		client := testAccProvider.Meta().(*client)
+		_, err := client.getPlaylist(context.Background(), rs.Primary.ID)
 
 		if err != nil {
 			return fmt.Errorf("error getting playlist: %w", err)
@@ -148,31 +206,20 @@ func testAccPlaylistDisappears() resource.TestCheckFunc {
 			return fmt.Errorf("resource id not set")
 		}
 
-		client := testAccProvider.Meta().(*client).gapi
-		id, err := strconv.Atoi(rs.Primary.ID)
-		if err != nil {
-			return err
-		}
-
-		err = client.DeletePlaylist(id)
-		return err
+		client := testAccProvider.Meta().(*client)
+		return client.deletePlaylist(context.Background(), rs.Primary.ID)
 	}
 }
 
 func testAccPlaylistDestroy(s *terraform.State) error {
-	client := testAccProvider.Meta().(*client).gapi
+	client := testAccProvider.Meta().(*client)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "grafana_playlist" {
 			continue
 		}
 
-		id, err := strconv.Atoi(rs.Primary.ID)
-		if err != nil {
-			return err
-		}
-
-		playlist, err := client.Playlist(id)
+		_, err := client.getPlaylist(context.Background(), rs.Primary.ID)
 
 		if err != nil {
 			if strings.HasPrefix(err.Error(), "status: 404") {
@@ -181,9 +228,7 @@ func testAccPlaylistDestroy(s *terraform.State) error {
 			return err
 		}
 
-		if playlist != nil {
-			return fmt.Errorf("Playlist still exists")
-		}
+		return fmt.Errorf("Playlist still exists")
 	}
 
 	return nil
@@ -223,3 +268,36 @@ resource "grafana_playlist" "test" {
 }
 `, name)
 }
+
+func TestPlaylistStateUpgradeV0(t *testing.T) {
+	IsUnitTest(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/api/playlists" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`[{"id": 1, "uid": "first", "name": "first"}, {"id": 2, "uid": "second", "name": "second"}]`))
+	}))
+	defer server.Close()
+	c := &client{gapiURL: server.URL, gapiConfig: &gapi.Config{}}
+
+	for _, tc := range []struct {
+		id          string
+		expectedID  string
+		expectedUID interface{}
+	}{
+		{id: "2", expectedID: "second", expectedUID: "second"},
+		{id: "3", expectedID: "3", expectedUID: nil},
+		{id: "first", expectedID: "first", expectedUID: nil},
+	} {
+		state, err := resourcePlaylistStateUpgradeV0(context.Background(), map[string]interface{}{"id": tc.id}, c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if state["id"] != tc.expectedID || state["uid"] != tc.expectedUID {
+			t.Errorf("expected playlist %s to be migrated to id %q and uid %v, got %v", tc.id, tc.expectedID, tc.expectedUID, state)
+		}
+	}
+}
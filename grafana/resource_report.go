@@ -23,6 +23,10 @@ func ResourceReport() *schema.Resource {
 		Description: `
 **Note:** This resource is available only with Grafana Enterprise 7.+.
 
+Reports are scheduled at the wall clock time of ` + "`schedule.start_time`" + ` in ` + "`schedule.timezone`" + `, repeated every hour, day, week,
+month (on the same day of the month, or on its last day) or custom interval. The Grafana reporting API can't store schedules
+on a weekday of the month, such as the first Monday of each month, so they can't be configured and such intervals are rejected.
+
 * [Official documentation](https://grafana.com/docs/grafana/latest/enterprise/reporting/)
 * [HTTP API](https://grafana.com/docs/grafana/latest/http_api/reporting/)
 `,
@@ -45,9 +49,74 @@ func ResourceReport() *schema.Resource {
 				Description: "Name of the report.",
 			},
 			"dashboard_id": {
-				Type:        schema.TypeInt,
-				Required:    true,
-				Description: "Dashboard to be sent in the report.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Dashboard to be sent in the report. Exactly one of `dashboard_id`, `dashboard_uid` or `dashboards` must be set.",
+				ExactlyOneOf: []string{"dashboard_id", "dashboard_uid", "dashboards"},
+			},
+			"dashboard_uid": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "UID of the dashboard to be sent in the report. Exactly one of `dashboard_id`, `dashboard_uid` or `dashboards` must be set.",
+			},
+			"dashboards": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MinItems:    1,
+				Description: "Dashboards to be sent in the report. Exactly one of `dashboard_id`, `dashboard_uid` or `dashboards` must be set.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"uid": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "UID of the dashboard.",
+						},
+						"time_range": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "Time range of the dashboard in the report.",
+							MaxItems:    1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"from": {
+										Type:        schema.TypeString,
+										Required:    true,
+										Description: "Start of the time range.",
+									},
+									"to": {
+										Type:        schema.TypeString,
+										Required:    true,
+										Description: "End of the time range.",
+									},
+								},
+							},
+						},
+						"template_variables": {
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Description: "Values of the dashboard's template variables.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:        schema.TypeString,
+										Required:    true,
+										Description: "Name of the template variable.",
+									},
+									"values": {
+										Type:        schema.TypeList,
+										Required:    true,
+										Description: "Values of the template variable.",
+										Elem: &schema.Schema{
+											Type: schema.TypeString,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
 			},
 			"recipients": {
 				Type:        schema.TypeList,
@@ -82,6 +151,16 @@ func ResourceReport() *schema.Resource {
 				Default:     false,
 				Description: "Whether to include a CSV file of table panel data.",
 			},
+			"formats": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Computed:    true,
+				Description: "Output formats of the report. Any of `" + strings.Join(reportFormats, "`, `") + "`.",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(reportFormats, false),
+				},
+			},
 			"layout": {
 				Type:         schema.TypeString,
 				Optional:     true,
@@ -132,10 +211,22 @@ func ResourceReport() *schema.Resource {
 							Description:  "Frequency of the report. One of `never`, `once`, `hourly`, `daily`, `weekly`, `monthly` or `custom`.",
 							ValidateFunc: validation.StringInSlice([]string{"never", "once", "hourly", "daily", "weekly", "monthly", "custom"}, false),
 						},
+						"timezone": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "GMT",
+							Description: "IANA time zone in which the report is scheduled, such as `Europe/Berlin`.",
+							ValidateFunc: func(i interface{}, k string) ([]string, []error) {
+								if _, err := time.LoadLocation(i.(string)); err != nil {
+									return nil, []error{fmt.Errorf("%s must be a valid IANA time zone: %w", k, err)}
+								}
+								return nil, nil
+							},
+						},
 						"start_time": {
 							Type:         schema.TypeString,
 							Optional:     true,
-							Description:  "Start time of the report. If empty, the start date will be set to the creation time. Note that times will be saved as UTC in Grafana.",
+							Description:  "Start time of the report. If empty, the start date will be set to the creation time. The report is sent at the wall clock time of the start time in `timezone`, also across DST changes. Weekly and monthly reports are sent on the weekday and day of the month of the start time.",
 							ValidateFunc: validation.IsRFC3339Time,
 							DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
 								oldParsed, _ := time.Parse(time.RFC3339, old)
@@ -152,7 +243,7 @@ func ResourceReport() *schema.Resource {
 						"end_time": {
 							Type:         schema.TypeString,
 							Optional:     true,
-							Description:  "End time of the report. If empty, the report will be sent indefinitely (according to frequency). Note that times will be saved as UTC in Grafana.",
+							Description:  "End time of the report. If empty, the report will be sent indefinitely (according to frequency).",
 							ValidateFunc: validation.IsRFC3339Time,
 							DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
 								oldParsed, _ := time.Parse(time.RFC3339, old)
@@ -169,10 +260,20 @@ func ResourceReport() *schema.Resource {
 								return !reportWorkdaysOnlyConfigAllowed(d.Get("schedule.0.frequency").(string))
 							},
 						},
+						"last_day_of_month": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Description: "Send the report on the last day of each month instead of the day of the month of `start_time`. Only available when frequency is set to `monthly`.",
+							Default:     false,
+							DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+								return d.Get("schedule.0.frequency").(string) != "monthly"
+							},
+						},
 						"custom_interval": {
 							Type:     schema.TypeString,
 							Optional: true,
-							Description: "Custom interval of the report.\n" +
+							Description: "Custom interval of the report, in the format `<number> <unit>` where unit is one of `hours`, `days`, `weeks`, `months`.\n" +
+								"Schedules on a weekday of the month (such as the first Monday of each month) aren't supported by Grafana and are rejected.\n" +
 								"**Note:** This field is only available when frequency is set to `custom`.",
 							ValidateDiagFunc: func(i interface{}, p cty.Path) diag.Diagnostics {
 								_, _, err := parseCustomReportInterval(i)
@@ -182,19 +283,50 @@ func ResourceReport() *schema.Resource {
 					},
 				},
 			},
+			"next_send_times": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The next times at which the report will be sent according to its schedule, in RFC3339 format. They are rendered when the schedule changes, and aren't refreshed as time passes.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+			// Only render the send times when the schedule changes, otherwise every plan would show a diff as time passes
+			if d.Id() != "" && !d.HasChange("schedule") {
+				return nil
+			}
+			for _, k := range []string{"frequency", "timezone", "start_time", "end_time", "workdays_only", "last_day_of_month", "custom_interval"} {
+				if !d.NewValueKnown("schedule.0." + k) {
+					return d.SetNewComputed("next_send_times")
+				}
+			}
+			schedule, err := schemaToReportSchedule(d.Get("schedule").([]interface{}))
+			if err != nil {
+				return err
+			}
+			if schedule.StartDate == nil {
+				// The start date is set to the creation time by the API
+				return d.SetNewComputed("next_send_times")
+			}
+			return d.SetNew("next_send_times", formatReportSendTimes(reportNextSendTimes(schedule, time.Now(), reportNextSendTimesCount)))
 		},
 	}
 }
 
+// reportNextSendTimesCount is the number of send times rendered in the `next_send_times` attribute.
+const reportNextSendTimesCount = 5
+
 func CreateReport(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*client).gapi
+	client := meta.(*client)
 
 	report, err := schemaToReport(d)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	id, err := client.NewReport(report)
+	id, err := client.newReport(ctx, report)
 	if err != nil {
 		data, _ := json.Marshal(report)
 		return diag.Errorf("error creating the following report:\n%s\n%v", string(data), err)
@@ -204,12 +336,12 @@ func CreateReport(ctx context.Context, d *schema.ResourceData, meta interface{})
 }
 
 func ReadReport(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*client).gapi
+	client := meta.(*client)
 	id, err := strconv.ParseInt(d.Id(), 10, 64)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	r, err := client.Report(id)
+	r, err := client.getReport(ctx, id)
 
 	if err != nil {
 		if strings.Contains(err.Error(), "role not found") {
@@ -221,13 +353,23 @@ func ReadReport(ctx context.Context, d *schema.ResourceData, meta interface{}) d
 		return diag.FromErr(err)
 	}
 
-	d.Set("dashboard_id", r.DashboardID)
+	dashboardID, dashboardUID := r.DashboardID, r.DashboardUID
+	if len(r.Dashboards) == 1 && dashboardUID == "" {
+		// Grafana 9+ only returns the report's dashboards in the `dashboards` list
+		dashboardID, dashboardUID = r.Dashboards[0].Dashboard.ID, r.Dashboards[0].Dashboard.UID
+	}
+	d.Set("dashboard_id", dashboardID)
+	d.Set("dashboard_uid", dashboardUID)
+	if _, ok := d.GetOk("dashboards"); ok || len(r.Dashboards) > 1 {
+		d.Set("dashboards", flattenReportDashboards(r.Dashboards))
+	}
 	d.Set("name", r.Name)
 	d.Set("recipients", strings.Split(r.Recipients, ","))
 	d.Set("reply_to", r.ReplyTo)
 	d.Set("message", r.Message)
 	d.Set("include_dashboard_link", r.EnableDashboardURL)
 	d.Set("include_table_csv", r.EnableCSV)
+	d.Set("formats", r.Formats)
 	d.Set("layout", r.Options.Layout)
 	d.Set("orientation", r.Options.Orientation)
 
@@ -240,27 +382,38 @@ func ReadReport(ctx context.Context, d *schema.ResourceData, meta interface{}) d
 		})
 	}
 
+	loc, err := time.LoadLocation(r.Schedule.TimeZone)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	schedule := map[string]interface{}{
-		"frequency":     r.Schedule.Frequency,
-		"workdays_only": r.Schedule.WorkdaysOnly,
+		"frequency":         r.Schedule.Frequency,
+		"workdays_only":     r.Schedule.WorkdaysOnly,
+		"timezone":          r.Schedule.TimeZone,
+		"last_day_of_month": r.Schedule.DayOfMonth == "last",
 	}
 	if r.Schedule.IntervalAmount != 0 && r.Schedule.IntervalFrequency != "" {
 		schedule["custom_interval"] = fmt.Sprintf("%d %s", r.Schedule.IntervalAmount, r.Schedule.IntervalFrequency)
 	}
 	if r.Schedule.StartDate != nil {
-		schedule["start_time"] = r.Schedule.StartDate.Format(time.RFC3339)
+		schedule["start_time"] = r.Schedule.StartDate.In(loc).Format(time.RFC3339)
 	}
 	if r.Schedule.EndDate != nil {
-		schedule["end_time"] = r.Schedule.EndDate.Format(time.RFC3339)
+		schedule["end_time"] = r.Schedule.EndDate.In(loc).Format(time.RFC3339)
 	}
 
 	d.Set("schedule", []interface{}{schedule})
+	// The send times are rendered when planning a schedule change. They are only computed here when they couldn't be
+	// planned (or on import), so that refreshing doesn't change them as time passes.
+	if _, ok := d.GetOk("next_send_times"); !ok {
+		d.Set("next_send_times", formatReportSendTimes(reportNextSendTimes(r.Schedule, time.Now(), reportNextSendTimesCount)))
+	}
 
 	return nil
 }
 
 func UpdateReport(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*client).gapi
+	client := meta.(*client)
 
 	report, err := schemaToReport(d)
 	if err != nil {
@@ -272,7 +425,7 @@ func UpdateReport(ctx context.Context, d *schema.ResourceData, meta interface{})
 	}
 	report.ID = int64(id)
 
-	if err := client.UpdateReport(report); err != nil {
+	if err := client.updateReport(ctx, report); err != nil {
 		data, _ := json.Marshal(report)
 		return diag.Errorf("error updating the following report:\n%s\n%v", string(data), err)
 	}
@@ -292,71 +445,153 @@ func DeleteReport(ctx context.Context, d *schema.ResourceData, meta interface{})
 	return nil
 }
 
-func schemaToReport(d *schema.ResourceData) (gapi.Report, error) {
-	frequency := d.Get("schedule.0.frequency").(string)
-	report := gapi.Report{
-		DashboardID:        int64(d.Get("dashboard_id").(int)),
-		Name:               d.Get("name").(string),
-		Recipients:         strings.Join(listToStringSlice(d.Get("recipients").([]interface{})), ","),
-		ReplyTo:            d.Get("reply_to").(string),
-		Message:            d.Get("message").(string),
-		EnableDashboardURL: d.Get("include_dashboard_link").(bool),
-		EnableCSV:          d.Get("include_table_csv").(bool),
-		Options: gapi.ReportOptions{
-			Layout:      d.Get("layout").(string),
-			Orientation: d.Get("orientation").(string),
-		},
-		Schedule: gapi.ReportSchedule{
-			Frequency: frequency,
-			TimeZone:  "GMT",
+func schemaToReport(d *schema.ResourceData) (report, error) {
+	schedule, err := schemaToReportSchedule(d.Get("schedule").([]interface{}))
+	if err != nil {
+		return report{}, err
+	}
+	r := report{
+		Report: gapi.Report{
+			Name:               d.Get("name").(string),
+			Recipients:         strings.Join(listToStringSlice(d.Get("recipients").([]interface{})), ","),
+			ReplyTo:            d.Get("reply_to").(string),
+			Message:            d.Get("message").(string),
+			EnableDashboardURL: d.Get("include_dashboard_link").(bool),
+			EnableCSV:          d.Get("include_table_csv").(bool),
+			Options: gapi.ReportOptions{
+				Layout:      d.Get("layout").(string),
+				Orientation: d.Get("orientation").(string),
+			},
 		},
+		Schedule: schedule,
 	}
 
+	// Set dashboards. Only send the dashboard reference that is configured, the other one is computed by the API
+	config := d.GetRawConfig()
+	switch {
+	case len(d.Get("dashboards").([]interface{})) > 0:
+		r.Dashboards = expandReportDashboards(d.Get("dashboards").([]interface{}))
+	case !config.IsNull() && !config.GetAttr("dashboard_uid").IsNull():
+		r.DashboardUID = d.Get("dashboard_uid").(string)
+	default:
+		r.DashboardID = int64(d.Get("dashboard_id").(int))
+	}
+
+	r.Formats = setToStringSlice(d.Get("formats").(*schema.Set))
+
 	// Set dashboard time range
 	timeRange := d.Get("time_range").([]interface{})
 	if len(timeRange) > 0 {
 		timeRange := timeRange[0].(map[string]interface{})
-		report.Options.TimeRange = gapi.ReportTimeRange{From: timeRange["from"].(string), To: timeRange["to"].(string)}
+		r.Options.TimeRange = gapi.ReportTimeRange{From: timeRange["from"].(string), To: timeRange["to"].(string)}
+	}
+
+	return r, nil
+}
+
+func schemaToReportSchedule(list []interface{}) (reportSchedule, error) {
+	s := list[0].(map[string]interface{})
+	frequency := s["frequency"].(string)
+	schedule := reportSchedule{ReportSchedule: gapi.ReportSchedule{
+		Frequency: frequency,
+		TimeZone:  s["timezone"].(string),
+	}}
+	loc, err := time.LoadLocation(schedule.TimeZone)
+	if err != nil {
+		return reportSchedule{}, err
 	}
 
 	// Set schedule start time
 	if frequency != "never" {
-		if startTimeStr := d.Get("schedule.0.start_time").(string); startTimeStr != "" {
+		if startTimeStr := s["start_time"].(string); startTimeStr != "" {
 			startDate, err := time.Parse(time.RFC3339, startTimeStr)
 			if err != nil {
-				return gapi.Report{}, err
+				return reportSchedule{}, err
 			}
-			startDate = startDate.UTC()
-			report.Schedule.StartDate = &startDate
+			startDate = startDate.In(loc)
+			schedule.StartDate = &startDate
 		}
 	}
 
 	// Set schedule end time
 	if frequency != "once" && frequency != "never" {
-		if endTimeStr := d.Get("schedule.0.end_time").(string); endTimeStr != "" {
+		if endTimeStr := s["end_time"].(string); endTimeStr != "" {
 			endDate, err := time.Parse(time.RFC3339, endTimeStr)
 			if err != nil {
-				return gapi.Report{}, err
+				return reportSchedule{}, err
 			}
-			endDate = endDate.UTC()
-			report.Schedule.EndDate = &endDate
+			endDate = endDate.In(loc)
+			schedule.EndDate = &endDate
 		}
 	}
 
 	if reportWorkdaysOnlyConfigAllowed(frequency) {
-		report.Schedule.WorkdaysOnly = d.Get("schedule.0.workdays_only").(bool)
+		schedule.WorkdaysOnly = s["workdays_only"].(bool)
+	}
+	if frequency == "monthly" && s["last_day_of_month"].(bool) {
+		schedule.DayOfMonth = "last"
 	}
 	if frequency == "custom" {
-		customInterval := d.Get("schedule.0.custom_interval").(string)
-		amount, unit, err := parseCustomReportInterval(customInterval)
+		amount, unit, err := parseCustomReportInterval(s["custom_interval"])
 		if err != nil {
-			return gapi.Report{}, err
+			return reportSchedule{}, err
+		}
+		schedule.IntervalAmount = int64(amount)
+		schedule.IntervalFrequency = unit
+	}
+
+	return schedule, nil
+}
+
+func expandReportDashboards(list []interface{}) []reportDashboard {
+	dashboards := make([]reportDashboard, 0, len(list))
+	for _, item := range list {
+		item := item.(map[string]interface{})
+		dashboard := reportDashboard{
+			Dashboard: reportDashboardRef{UID: item["uid"].(string)},
+		}
+		if timeRange := item["time_range"].([]interface{}); len(timeRange) > 0 && timeRange[0] != nil {
+			timeRange := timeRange[0].(map[string]interface{})
+			dashboard.TimeRange = gapi.ReportTimeRange{From: timeRange["from"].(string), To: timeRange["to"].(string)}
 		}
-		report.Schedule.IntervalAmount = int64(amount)
-		report.Schedule.IntervalFrequency = unit
+		if variables := item["template_variables"].(*schema.Set).List(); len(variables) > 0 {
+			dashboard.ReportVariables = make(map[string][]string, len(variables))
+			for _, variable := range variables {
+				variable := variable.(map[string]interface{})
+				dashboard.ReportVariables[variable["name"].(string)] = listToStringSlice(variable["values"].([]interface{}))
+			}
+		}
+		dashboards = append(dashboards, dashboard)
 	}
+	return dashboards
+}
 
-	return report, nil
+func flattenReportDashboards(dashboards []reportDashboard) []interface{} {
+	list := make([]interface{}, 0, len(dashboards))
+	for _, dashboard := range dashboards {
+		item := map[string]interface{}{
+			"uid":        dashboard.Dashboard.UID,
+			"time_range": []interface{}{},
+		}
+		if dashboard.TimeRange.From != "" {
+			item["time_range"] = []interface{}{
+				map[string]interface{}{
+					"from": dashboard.TimeRange.From,
+					"to":   dashboard.TimeRange.To,
+				},
+			}
+		}
+		variables := make([]interface{}, 0, len(dashboard.ReportVariables))
+		for name, values := range dashboard.ReportVariables {
+			variables = append(variables, map[string]interface{}{
+				"name":   name,
+				"values": stringSliceToList(values),
+			})
+		}
+		item["template_variables"] = variables
+		list = append(list, item)
+	}
+	return list
 }
 
 func reportWorkdaysOnlyConfigAllowed(frequency string) bool {
@@ -367,6 +602,14 @@ func parseCustomReportInterval(i interface{}) (int, string, error) {
 	parseErr := errors.New("custom_interval must be in format `<number> <unit>` where unit is one of `hours`, `days`, `weeks`, `months`")
 
 	v := i.(string)
+	for _, word := range strings.Fields(strings.ToLower(v)) {
+		for day := time.Sunday; day <= time.Saturday; day++ {
+			if strings.TrimSuffix(word, "s") == strings.ToLower(day.String()) {
+				return 0, "", fmt.Errorf("custom_interval %q is not supported: Grafana can't schedule reports on a weekday of the month, use a monthly schedule starting on a given day instead", v)
+			}
+		}
+	}
+
 	split := strings.Split(v, " ")
 	if len(split) != 2 {
 		return 0, "", parseErr
@@ -384,3 +627,112 @@ func parseCustomReportInterval(i interface{}) (int, string, error) {
 
 	return number, unit, nil
 }
+
+// reportNextSendTimes returns up to count times after now at which a report with the given schedule is sent.
+// The times are computed in the schedule's time zone, so that the reports are sent at the same wall clock time across DST changes.
+func reportNextSendTimes(schedule reportSchedule, now time.Time, count int) []time.Time {
+	loc, err := time.LoadLocation(schedule.TimeZone)
+	if err != nil {
+		return nil
+	}
+	start := now
+	if schedule.StartDate != nil {
+		start = *schedule.StartDate
+	}
+	start = start.In(loc)
+
+	// occurrence returns the k-th send time, and the longest possible duration between two send times.
+	var occurrence func(k int) time.Time
+	var maxStep time.Duration
+	amount := int(schedule.IntervalAmount)
+	unit := schedule.IntervalFrequency
+	switch schedule.Frequency {
+	case "once":
+		occurrence, maxStep = func(k int) time.Time { return start }, 0
+	case "hourly":
+		amount, unit = 1, "hours"
+	case "daily":
+		amount, unit = 1, "days"
+	case "weekly":
+		amount, unit = 1, "weeks"
+	case "monthly":
+		amount, unit = 1, "months"
+	case "custom":
+		if amount <= 0 {
+			return nil
+		}
+	default:
+		return nil
+	}
+	if occurrence == nil {
+		switch unit {
+		case "hours":
+			occurrence, maxStep = func(k int) time.Time { return start.Add(time.Duration(k*amount) * time.Hour) }, time.Duration(amount)*time.Hour
+		case "days":
+			occurrence, maxStep = func(k int) time.Time { return start.AddDate(0, 0, k*amount) }, time.Duration(amount)*25*time.Hour
+		case "weeks":
+			occurrence, maxStep = func(k int) time.Time { return start.AddDate(0, 0, 7*k*amount) }, time.Duration(amount)*(7*24+1)*time.Hour
+		case "months":
+			occurrence, maxStep = func(k int) time.Time { return reportAddMonths(start, k*amount, schedule.DayOfMonth == "last") }, time.Duration(amount)*(31*24+1)*time.Hour
+		default:
+			return nil
+		}
+	}
+
+	// Skip the occurrences that are surely in the past
+	k := 0
+	if maxStep > 0 && now.After(start) {
+		k = int(now.Sub(start)/maxStep) - 1
+		if k < 0 {
+			k = 0
+		}
+	}
+
+	var times []time.Time
+	skipped := 0
+	for ; len(times) < count; k++ {
+		t := occurrence(k)
+		if schedule.EndDate != nil && t.After(*schedule.EndDate) {
+			break
+		}
+		if schedule.WorkdaysOnly && (t.Weekday() == time.Saturday || t.Weekday() == time.Sunday) {
+			// Intervals of whole weeks always fall on the same weekday, so a report starting on a weekend is never sent
+			if skipped++; skipped > reportMaxSkippedOccurrences {
+				break
+			}
+		} else {
+			skipped = 0
+			if t.After(now) {
+				times = append(times, t)
+			}
+		}
+		if maxStep == 0 {
+			break
+		}
+	}
+	return times
+}
+
+// reportMaxSkippedOccurrences is the number of consecutive weekend occurrences after which a workdays only schedule
+// is considered to never be sent. It is larger than the number of hourly occurrences in a weekend.
+const reportMaxSkippedOccurrences = 100
+
+// reportAddMonths adds months to t, keeping its wall clock time. If the day of the month doesn't exist in the resulting month,
+// the last day of that month is used instead, like when lastDayOfMonth is set.
+func reportAddMonths(t time.Time, months int, lastDayOfMonth bool) time.Time {
+	year, month, day := t.Date()
+	firstOfMonth := time.Date(year, month+time.Month(months), 1, t.Hour(), t.Minute(), t.Second(), 0, t.Location())
+	lastDay := firstOfMonth.AddDate(0, 1, -1).Day()
+	if lastDayOfMonth || day > lastDay {
+		day = lastDay
+	}
+	return firstOfMonth.AddDate(0, 0, day-1)
+}
+
+func formatReportSendTimes(times []time.Time) []string {
+	formatted := make([]string, 0, len(times))
+	for _, t := range times {
+		formatted = append(formatted, t.Format(time.RFC3339))
+	}
+	return formatted
+}
@@ -0,0 +1,140 @@
+package grafana
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func ResourceReportSettings() *schema.Resource {
+	return &schema.Resource{
+		Description: `
+Manages the branding and default settings of the reports of an organization.
+Only one ` + "`grafana_report_settings`" + ` resource should be defined per organization.
+Destroying the resource resets the settings to their defaults.
+
+**Note:** This resource is available only with Grafana Enterprise 7.+.
+
+* [Official documentation](https://grafana.com/docs/grafana/latest/enterprise/reporting/#report-settings)
+`,
+		CreateContext: UpdateReportSettings,
+		UpdateContext: UpdateReportSettings,
+		ReadContext:   ReadReportSettings,
+		DeleteContext: DeleteReportSettings,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"report_logo_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "URL of the logo displayed in the reports' PDF documents.",
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+			},
+			"email_logo_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "URL of the image displayed in the header of the report emails.",
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+			},
+			"email_footer_mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "sent-by",
+				Description:  "Footer of the report emails. `sent-by` shows `email_footer_text` linking to `email_footer_link`, `none` shows no footer.",
+				ValidateFunc: validation.StringInSlice([]string{"sent-by", "none"}, false),
+			},
+			"email_footer_text": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "Grafana Labs",
+				Description: "Text of the footer of the report emails.",
+			},
+			"email_footer_link": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "https://grafana.com/",
+				Description:  "Link of the footer of the report emails.",
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+			},
+			"pdf_theme": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "light",
+				Description:  "Theme of the reports' PDF documents. `light` or `dark`.",
+				ValidateFunc: validation.StringInSlice([]string{"light", "dark"}, false),
+			},
+			"embedded_image_theme": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "dark",
+				Description:  "Theme of the dashboard images embedded in the report emails. `light` or `dark`.",
+				ValidateFunc: validation.StringInSlice([]string{"light", "dark"}, false),
+			},
+		},
+	}
+}
+
+func UpdateReportSettings(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*client)
+
+	settings := reportSettings{
+		Branding: reportSettingsBranding{
+			ReportLogoURL:   d.Get("report_logo_url").(string),
+			EmailLogoURL:    d.Get("email_logo_url").(string),
+			EmailFooterMode: d.Get("email_footer_mode").(string),
+			EmailFooterText: d.Get("email_footer_text").(string),
+			EmailFooterLink: d.Get("email_footer_link").(string),
+		},
+		PDFTheme:           d.Get("pdf_theme").(string),
+		EmbeddedImageTheme: d.Get("embedded_image_theme").(string),
+	}
+	if err := client.saveReportSettings(ctx, settings); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return ReadReportSettings(ctx, d, meta)
+}
+
+func ReadReportSettings(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*client)
+
+	settings, err := client.getReportSettings(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(strconv.FormatInt(settings.OrgID, 10))
+	d.Set("report_logo_url", settings.Branding.ReportLogoURL)
+	d.Set("email_logo_url", settings.Branding.EmailLogoURL)
+	d.Set("email_footer_mode", settings.Branding.EmailFooterMode)
+	d.Set("email_footer_text", settings.Branding.EmailFooterText)
+	d.Set("email_footer_link", settings.Branding.EmailFooterLink)
+	d.Set("pdf_theme", settings.PDFTheme)
+	d.Set("embedded_image_theme", settings.EmbeddedImageTheme)
+
+	return nil
+}
+
+func DeleteReportSettings(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// The settings can't be deleted, they are reset to the defaults instead.
+	client := meta.(*client)
+
+	defaults := reportSettings{
+		Branding: reportSettingsBranding{
+			EmailFooterMode: "sent-by",
+			EmailFooterText: "Grafana Labs",
+			EmailFooterLink: "https://grafana.com/",
+		},
+		PDFTheme:           "light",
+		EmbeddedImageTheme: "dark",
+	}
+	if err := client.saveReportSettings(ctx, defaults); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
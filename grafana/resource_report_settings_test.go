@@ -0,0 +1,50 @@
+package grafana
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResourceReportSettings(t *testing.T) {
+	CheckCloudInstanceTestsEnabled(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccReportSettingsCheckDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExample(t, "resources/grafana_report_settings/resource.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("grafana_report_settings.settings", "id"),
+					resource.TestCheckResourceAttr("grafana_report_settings.settings", "report_logo_url", "https://grafana.com/static/assets/img/fav32.png"),
+					resource.TestCheckResourceAttr("grafana_report_settings.settings", "email_footer_mode", "sent-by"),
+					resource.TestCheckResourceAttr("grafana_report_settings.settings", "email_footer_text", "Example Corp."),
+					resource.TestCheckResourceAttr("grafana_report_settings.settings", "email_footer_link", "https://example.com/"),
+					resource.TestCheckResourceAttr("grafana_report_settings.settings", "pdf_theme", "dark"),
+					resource.TestCheckResourceAttr("grafana_report_settings.settings", "embedded_image_theme", "dark"),
+				),
+			},
+			{
+				ResourceName:      "grafana_report_settings.settings",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccReportSettingsCheckDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*client)
+	settings, err := client.getReportSettings(context.Background())
+	if err != nil {
+		return err
+	}
+	if settings.Branding.ReportLogoURL != "" || settings.Branding.EmailFooterText != "Grafana Labs" {
+		return fmt.Errorf("report settings were not reset: %+v", settings)
+	}
+	return nil
+}
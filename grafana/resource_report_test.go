@@ -1,12 +1,19 @@
 package grafana
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"regexp"
 	"strconv"
 	"testing"
+	"time"
 
 	gapi "github.com/grafana/grafana-api-golang-client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
@@ -65,10 +72,86 @@ func TestAccResourceReport(t *testing.T) {
 					resource.TestCheckResourceAttr("grafana_report.test", "time_range.0.to", "now"),
 				),
 			},
+			{
+				Config: testAccExample(t, "resources/grafana_report/multiple-dashboards.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccReportCheckExists("grafana_report.test", &report),
+					resource.TestCheckResourceAttr("grafana_report.test", "schedule.0.frequency", "weekly"),
+					resource.TestCheckResourceAttr("grafana_report.test", "schedule.0.timezone", "Europe/Berlin"),
+					resource.TestCheckResourceAttr("grafana_report.test", "formats.#", "3"),
+					resource.TestCheckResourceAttr("grafana_report.test", "dashboards.#", "2"),
+					resource.TestCheckResourceAttr("grafana_report.test", "dashboards.0.uid", "report"),
+					resource.TestCheckResourceAttr("grafana_report.test", "dashboards.0.time_range.0.from", "now-1d"),
+					resource.TestCheckResourceAttr("grafana_report.test", "dashboards.0.template_variables.#", "1"),
+					resource.TestCheckTypeSetElemNestedAttrs("grafana_report.test", "dashboards.0.template_variables.*", map[string]string{
+						"name":     "environment",
+						"values.#": "2",
+						"values.0": "prod",
+						"values.1": "staging",
+					}),
+					resource.TestCheckResourceAttr("grafana_report.test", "dashboards.1.uid", "report2"),
+					resource.TestCheckResourceAttr("grafana_report.test", "dashboards.1.time_range.#", "0"),
+				),
+			},
+			{
+				Config: testAccExample(t, "resources/grafana_report/monthly.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("grafana_report.test", "dashboard_uid", "report"),
+					resource.TestCheckResourceAttr("grafana_report.test", "schedule.0.frequency", "monthly"),
+					resource.TestCheckResourceAttr("grafana_report.test", "schedule.0.start_time", "2020-01-31T09:00:00+01:00"),
+					resource.TestCheckResourceAttr("grafana_report.test", "schedule.0.last_day_of_month", "true"),
+					resource.TestCheckResourceAttr("grafana_report.test", "next_send_times.#", "5"),
+				),
+			},
 		},
 	})
 }
 
+func TestReportDashboardsUnit(t *testing.T) {
+	IsUnitTest(t)
+
+	variablesSchema := ResourceReport().Schema["dashboards"].Elem.(*schema.Resource).Schema["template_variables"].Elem.(*schema.Resource)
+	variables := []interface{}{
+		map[string]interface{}{"name": "environment", "values": []interface{}{"prod", "staging"}},
+		map[string]interface{}{"name": "matcher", "values": []interface{}{"job=~\"a,b\""}},
+	}
+	list := []interface{}{
+		map[string]interface{}{
+			"uid": "report",
+			"time_range": []interface{}{
+				map[string]interface{}{"from": "now-1d", "to": "now"},
+			},
+			"template_variables": schema.NewSet(schema.HashResource(variablesSchema), variables),
+		},
+		map[string]interface{}{
+			"uid":                "report2",
+			"time_range":         []interface{}{},
+			"template_variables": schema.NewSet(schema.HashResource(variablesSchema), nil),
+		},
+	}
+
+	dashboards := expandReportDashboards(list)
+	if got := dashboards[0].ReportVariables["environment"]; !reflect.DeepEqual(got, []string{"prod", "staging"}) {
+		t.Errorf("expected the variable values to be kept, got %v", got)
+	}
+	if got := dashboards[0].ReportVariables["matcher"]; !reflect.DeepEqual(got, []string{`job=~"a,b"`}) {
+		t.Errorf("expected values containing commas to be kept, got %v", got)
+	}
+
+	flattened := flattenReportDashboards(dashboards)
+	for i, item := range flattened {
+		item := item.(map[string]interface{})
+		expected := list[i].(map[string]interface{})
+		got := schema.NewSet(schema.HashResource(variablesSchema), item["template_variables"].([]interface{}))
+		if !got.Equal(expected["template_variables"]) {
+			t.Errorf("expected variables %v, got %v", expected["template_variables"].(*schema.Set).List(), got.List())
+		}
+		if item["uid"] != expected["uid"] || !reflect.DeepEqual(item["time_range"], expected["time_range"]) {
+			t.Errorf("expected %v, got %v", expected, item)
+		}
+	}
+}
+
 func testAccReportCheckExists(rn string, report *gapi.Report) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[rn]
@@ -110,3 +193,185 @@ func testAccReportCheckDestroy(report *gapi.Report) resource.TestCheckFunc {
 		return nil
 	}
 }
+
+func TestReportNextSendTimesUnit(t *testing.T) {
+	IsUnitTest(t)
+
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Date(2022, time.March, 25, 9, 0, 0, 0, berlin)
+	end := time.Date(2022, time.March, 29, 9, 0, 0, 0, berlin)
+	saturday := time.Date(2022, time.March, 26, 9, 0, 0, 0, berlin)
+	now := time.Date(2022, time.March, 24, 0, 0, 0, 0, time.UTC)
+
+	for _, tc := range []struct {
+		name     string
+		schedule gapi.ReportSchedule
+		last     bool
+		now      time.Time
+		expected []string
+	}{
+		{
+			name:     "daily across DST",
+			schedule: gapi.ReportSchedule{Frequency: "daily", TimeZone: "Europe/Berlin", StartDate: &start},
+			now:      now,
+			expected: []string{"2022-03-25T09:00:00+01:00", "2022-03-26T09:00:00+01:00", "2022-03-27T09:00:00+02:00"},
+		},
+		{
+			name:     "workdays only with end date",
+			schedule: gapi.ReportSchedule{Frequency: "daily", TimeZone: "Europe/Berlin", StartDate: &start, EndDate: &end, WorkdaysOnly: true},
+			now:      now,
+			expected: []string{"2022-03-25T09:00:00+01:00", "2022-03-28T09:00:00+02:00", "2022-03-29T09:00:00+02:00"},
+		},
+		{
+			name:     "monthly",
+			schedule: gapi.ReportSchedule{Frequency: "monthly", TimeZone: "Europe/Berlin", StartDate: &start},
+			now:      time.Date(2022, time.October, 1, 0, 0, 0, 0, time.UTC),
+			expected: []string{"2022-10-25T09:00:00+02:00", "2022-11-25T09:00:00+01:00", "2022-12-25T09:00:00+01:00"},
+		},
+		{
+			name:     "monthly on the last day",
+			schedule: gapi.ReportSchedule{Frequency: "monthly", TimeZone: "Europe/Berlin", StartDate: &start},
+			last:     true,
+			now:      now,
+			expected: []string{"2022-03-31T09:00:00+02:00", "2022-04-30T09:00:00+02:00", "2022-05-31T09:00:00+02:00"},
+		},
+		{
+			name:     "custom interval in UTC",
+			schedule: gapi.ReportSchedule{Frequency: "custom", TimeZone: "GMT", StartDate: &start, IntervalAmount: 2, IntervalFrequency: "weeks"},
+			now:      time.Date(2022, time.April, 1, 0, 0, 0, 0, time.UTC),
+			expected: []string{"2022-04-08T08:00:00Z", "2022-04-22T08:00:00Z", "2022-05-06T08:00:00Z"},
+		},
+		{
+			name:     "weekly workdays only starting on a Saturday",
+			schedule: gapi.ReportSchedule{Frequency: "custom", TimeZone: "Europe/Berlin", StartDate: &saturday, IntervalAmount: 1, IntervalFrequency: "weeks", WorkdaysOnly: true},
+			now:      now,
+			expected: []string{},
+		},
+		{
+			name:     "every 7 days workdays only starting on a Saturday",
+			schedule: gapi.ReportSchedule{Frequency: "custom", TimeZone: "Europe/Berlin", StartDate: &saturday, IntervalAmount: 7, IntervalFrequency: "days", WorkdaysOnly: true},
+			now:      now,
+			expected: []string{},
+		},
+		{
+			name:     "hourly workdays only across a weekend",
+			schedule: gapi.ReportSchedule{Frequency: "hourly", TimeZone: "Europe/Berlin", StartDate: &saturday, WorkdaysOnly: true},
+			now:      now,
+			expected: []string{"2022-03-28T00:00:00+02:00", "2022-03-28T01:00:00+02:00", "2022-03-28T02:00:00+02:00"},
+		},
+		{
+			name:     "once in the past",
+			schedule: gapi.ReportSchedule{Frequency: "once", TimeZone: "Europe/Berlin", StartDate: &start},
+			now:      time.Date(2022, time.April, 1, 0, 0, 0, 0, time.UTC),
+			expected: []string{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			schedule := reportSchedule{ReportSchedule: tc.schedule}
+			if tc.last {
+				schedule.DayOfMonth = "last"
+			}
+			got := formatReportSendTimes(reportNextSendTimes(schedule, tc.now, 3))
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestParseCustomReportIntervalUnit(t *testing.T) {
+	IsUnitTest(t)
+
+	for _, tc := range []struct {
+		interval  string
+		amount    int
+		unit      string
+		errRegexp string
+	}{
+		{interval: "2 weeks", amount: 2, unit: "weeks"},
+		{interval: "7 days", amount: 7, unit: "days"},
+		{interval: "2 fortnights", errRegexp: "custom_interval must be in format"},
+		{interval: "first Monday", errRegexp: `custom_interval "first Monday" is not supported`},
+		{interval: "1 mondays", errRegexp: `custom_interval "1 mondays" is not supported`},
+	} {
+		amount, unit, err := parseCustomReportInterval(tc.interval)
+		if tc.errRegexp != "" {
+			if err == nil || !regexp.MustCompile(tc.errRegexp).MatchString(err.Error()) {
+				t.Errorf("%s: expected an error matching %q, got %v", tc.interval, tc.errRegexp, err)
+			}
+			continue
+		}
+		if err != nil || amount != tc.amount || unit != tc.unit {
+			t.Errorf("%s: expected %d %s, got %d %s (%v)", tc.interval, tc.amount, tc.unit, amount, unit, err)
+		}
+	}
+}
+
+func TestReportNextSendTimesPlanUnit(t *testing.T) {
+	IsUnitTest(t)
+
+	// Value of the attributes that are unknown until apply, such as references to other resources
+	const unknown = "74D93920-ED26-11E3-AC10-0800200C9A66"
+	start := time.Now().Add(24 * time.Hour).UTC().Format(time.RFC3339)
+
+	for name, tc := range map[string]struct {
+		schedule map[string]interface{}
+		computed bool
+	}{
+		"known schedule":          {map[string]interface{}{"frequency": "daily", "start_time": start}, false},
+		"unknown start time":      {map[string]interface{}{"frequency": "daily", "start_time": unknown}, true},
+		"unknown frequency":       {map[string]interface{}{"frequency": unknown, "start_time": start}, true},
+		"unknown custom interval": {map[string]interface{}{"frequency": "custom", "start_time": start, "custom_interval": unknown}, true},
+	} {
+		config := terraform.NewResourceConfigRaw(map[string]interface{}{
+			"name":          "report",
+			"dashboard_uid": "dashboard",
+			"recipients":    []interface{}{"user@example.com"},
+			"schedule":      []interface{}{tc.schedule},
+		})
+		diff, err := ResourceReport().Diff(context.Background(), nil, config, nil)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+			continue
+		}
+		attr := diff.Attributes["next_send_times.#"]
+		if tc.computed && (attr == nil || !attr.NewComputed) {
+			t.Errorf("%s: expected the send times to be computed, got %#v", name, attr)
+		}
+		if !tc.computed && (attr == nil || attr.NewComputed || attr.New != strconv.Itoa(reportNextSendTimesCount)) {
+			t.Errorf("%s: expected %d send times to be planned, got %#v", name, reportNextSendTimesCount, attr)
+		}
+	}
+}
+
+func TestReadReportKeepsNextSendTimesUnit(t *testing.T) {
+	IsUnitTest(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": 1, "name": "report", "dashboardUid": "dashboard", "recipients": "user@example.com", "schedule": {"frequency": "hourly", "timeZone": "GMT", "startDate": "2022-03-25T09:00:00Z"}}`)
+	}))
+	defer server.Close()
+	meta := &client{gapiURL: server.URL, gapiConfig: &gapi.Config{}}
+
+	d := ResourceReport().TestResourceData()
+	d.SetId("1")
+	if diags := ReadReport(context.Background(), d, meta); diags.HasError() {
+		t.Fatal(diags)
+	}
+	rendered := d.Get("next_send_times").([]interface{})
+	if len(rendered) != reportNextSendTimesCount {
+		t.Fatalf("expected the send times to be rendered on import, got %v", rendered)
+	}
+
+	planned := []interface{}{"2022-03-25T09:00:00Z"}
+	d.Set("next_send_times", planned)
+	if diags := ReadReport(context.Background(), d, meta); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if got := d.Get("next_send_times").([]interface{}); !reflect.DeepEqual(got, planned) {
+		t.Errorf("expected refreshing to keep the send times, got %v", got)
+	}
+}
@@ -3,8 +3,16 @@ package grafana
 import (
 	"context"
 	"fmt"
+	"log"
+	"net"
+	"net/url"
+	"regexp"
+	"sort"
 	"strconv"
+	"strings"
 
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/go-multierror"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -102,6 +110,20 @@ var (
 				MaxItems:    1,
 				Elem:        syntheticMonitoringCheckSettingsTraceroute,
 			},
+			"multihttp": {
+				Description: "Settings for a MultiHTTP check, which runs a sequence of HTTP requests. The target must be a URL (http or https), usually the URL of the first request.",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				MaxItems:    1,
+				Elem:        syntheticMonitoringCheckSettingsMultiHTTP,
+			},
+			"scripted": {
+				Description: "Settings for a scripted check, which runs a k6 script. The target is only used as the `instance` label of the check's metrics and logs.",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				MaxItems:    1,
+				Elem:        syntheticMonitoringCheckSettingsScripted,
+			},
 		},
 	}
 
@@ -176,7 +198,8 @@ var (
 				Type:        schema.TypeSet,
 				Optional:    true,
 				Elem: &schema.Schema{
-					Type: schema.TypeString,
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsValidRegExp,
 				},
 			},
 			"fail_if_not_matches_regexp": {
@@ -184,7 +207,8 @@ var (
 				Type:        schema.TypeSet,
 				Optional:    true,
 				Elem: &schema.Schema{
-					Type: schema.TypeString,
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsValidRegExp,
 				},
 			},
 		},
@@ -269,7 +293,8 @@ var (
 				Type:        schema.TypeSet,
 				Optional:    true,
 				Elem: &schema.Schema{
-					Type: schema.TypeString,
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsValidRegExp,
 				},
 			},
 			"fail_if_body_not_matches_regexp": {
@@ -277,7 +302,8 @@ var (
 				Type:        schema.TypeSet,
 				Optional:    true,
 				Elem: &schema.Schema{
-					Type: schema.TypeString,
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsValidRegExp,
 				},
 			},
 			"fail_if_header_matches_regexp": {
@@ -323,9 +349,10 @@ var (
 				Required:    true,
 			},
 			"regexp": {
-				Description: "Regex that header value should match.",
-				Type:        schema.TypeString,
-				Required:    true,
+				Description:  "Regex that header value should match.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
 			},
 			"allow_missing": {
 				Description: "Allow header to be missing from responses.",
@@ -426,6 +453,181 @@ var (
 			},
 		},
 	}
+
+	syntheticMonitoringCheckSettingsMultiHTTP = &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"entries": {
+				Description: "The HTTP requests to run, in order. Variables extracted from the response of a request can be used in the following requests with the `${variable_name}` syntax.",
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Elem:        syntheticMonitoringCheckSettingsMultiHTTPEntry,
+			},
+		},
+	}
+
+	syntheticMonitoringCheckSettingsMultiHTTPEntry = &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"request": {
+				Description: "The HTTP request to run.",
+				Type:        schema.TypeSet,
+				Required:    true,
+				MaxItems:    1,
+				Elem:        syntheticMonitoringCheckSettingsMultiHTTPRequest,
+			},
+			"assertions": {
+				Description: "Assertions made on the response. The check fails if any of them fails.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        syntheticMonitoringCheckSettingsMultiHTTPAssertion,
+			},
+			"variables": {
+				Description: "Variables to extract from the response, to be used in the following requests.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        syntheticMonitoringCheckSettingsMultiHTTPVariable,
+			},
+		},
+	}
+
+	syntheticMonitoringCheckSettingsMultiHTTPNameValue = &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Description: "Name.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"value": {
+				Description: "Value.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+		},
+	}
+
+	syntheticMonitoringCheckSettingsMultiHTTPRequest = &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"method": {
+				Description: "Request method. One of `GET`, `CONNECT`, `DELETE`, `HEAD`, `OPTIONS`, `POST`, `PUT`, `TRACE`",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "GET",
+			},
+			"url": {
+				Description: "The URL of the request.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"headers": {
+				Description: "The HTTP headers of the request.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        syntheticMonitoringCheckSettingsMultiHTTPNameValue,
+			},
+			"query_fields": {
+				Description: "Query parameters added to the URL.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        syntheticMonitoringCheckSettingsMultiHTTPNameValue,
+			},
+			"body": {
+				Description: "The body of the request.",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"content_type": {
+							Description: "The `Content-Type` header of the request.",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+						"content_encoding": {
+							Description: "The `Content-Encoding` header of the request.",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+						"payload": {
+							Description: "The body's content.",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	syntheticMonitoringCheckSettingsMultiHTTPAssertion = &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"type": {
+				Description:  "One of `" + strings.Join(smMultiHTTPAssertionTypes, "`, `") + "`.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(smMultiHTTPAssertionTypes, false),
+			},
+			"subject": {
+				Description:  "The part of the response the assertion is made on. One of `" + strings.Join(smMultiHTTPAssertionSubjects, "`, `") + "`.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      smMultiHTTPAssertionSubjects[0],
+				ValidateFunc: validation.StringInSlice(smMultiHTTPAssertionSubjects, false),
+			},
+			"condition": {
+				Description:  "The condition of the assertion. One of `" + strings.Join(smMultiHTTPAssertionConditions, "`, `") + "`.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      smMultiHTTPAssertionConditions[0],
+				ValidateFunc: validation.StringInSlice(smMultiHTTPAssertionConditions, false),
+			},
+			"value": {
+				Description: "The value the subject is compared to.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"expression": {
+				Description: "The JSON path or regex used to select the value to assert on, depending on the type.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+		},
+	}
+
+	syntheticMonitoringCheckSettingsMultiHTTPVariable = &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"type": {
+				Description:  "How the variable is extracted. One of `" + strings.Join(smMultiHTTPVariableTypes, "`, `") + "`.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(smMultiHTTPVariableTypes, false),
+			},
+			"name": {
+				Description: "Name of the variable.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"expression": {
+				Description: "The JSON path, regex or CSS selector used to extract the variable's value.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"attribute": {
+				Description: "For `CSS_SELECTOR` variables, the attribute of the selected element to use as value.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+		},
+	}
+
+	syntheticMonitoringCheckSettingsScripted = &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"script": {
+				Description: "The k6 script to run. Use the `file` function to load it from a file.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+		},
+	}
 )
 
 func ResourceSyntheticMonitoringCheck() *schema.Resource {
@@ -438,6 +640,9 @@ target for checks can be a domain name, a server, or a website, depending on
 what information you would like to gather about your endpoint. You can define
 multiple checks for a single endpoint to check different capabilities.
 
+When ` + "`sm_access_token`" + ` is set, new checks and their labels are validated against the
+limits of the tenant when planning. Checks created in the same apply are only counted by the SM API.
+
 * [Official documentation](https://grafana.com/docs/grafana-cloud/synthetic-monitoring/checks/)
 `,
 
@@ -506,13 +711,57 @@ multiple checks for a single endpoint to check different capabilities.
 				Default:  true,
 			},
 			"probes": {
-				Description: "List of probe location IDs where this target will be checked from.",
-				Type:        schema.TypeSet,
-				Required:    true,
+				Description: "List of probe location IDs where this target will be checked from. " +
+					"Computed when `probe_names` or `probe_selector` is used.",
+				Type:         schema.TypeSet,
+				Optional:     true,
+				Computed:     true,
+				AtLeastOneOf: []string{"probes", "probe_names", "probe_selector"},
 				Elem: &schema.Schema{
 					Type: schema.TypeInt,
 				},
 			},
+			"probe_names": {
+				Description:   "Names of the probes where this target will be checked from. They are resolved to IDs (in `probes`) when planning.",
+				Type:          schema.TypeSet,
+				Optional:      true,
+				ConflictsWith: []string{"probes", "probe_selector"},
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"probe_selector": {
+				Description: "Selects the probes where this target will be checked from. " +
+					"The matching probes are resolved to IDs (in `probes`) when planning, so new probes matching the selector are picked up by the next apply. " +
+					"Deprecated probes are never selected, and an empty block selects all the other probes.",
+				Type:          schema.TypeList,
+				Optional:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"probes", "probe_names"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"region": {
+							Description: "If set, only probes in this region are selected.",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+						"visibility": {
+							Description:  "If set, only `public` (run by Grafana Labs) or `private` probes are selected.",
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringInSlice([]string{"public", "private"}, false),
+						},
+						"labels": {
+							Description: "If set, only probes that have all of these labels (with the same values) are selected.",
+							Type:        schema.TypeMap,
+							Optional:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
 			"labels": {
 				Description: "Custom labels to be included with collected metrics and logs. " +
 					"The maximum number of labels that can be specified per check is 5. " +
@@ -536,9 +785,9 @@ multiple checks for a single endpoint to check different capabilities.
 }
 
 func resourceSyntheticMonitoringCheckCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	c := meta.(*client).smapi
+	c := meta.(*client)
 	chk := makeCheck(d)
-	res, err := c.AddCheck(ctx, *chk)
+	res, err := c.smAddCheck(ctx, *chk)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -548,12 +797,12 @@ func resourceSyntheticMonitoringCheckCreate(ctx context.Context, d *schema.Resou
 }
 
 func resourceSyntheticMonitoringCheckRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	c := meta.(*client).smapi
+	c := meta.(*client)
 	id, err := strconv.ParseInt(d.Id(), 10, 64)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	chk, err := c.GetCheck(ctx, id)
+	chk, err := c.smGetCheck(ctx, id)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -577,8 +826,13 @@ func resourceSyntheticMonitoringCheckRead(ctx context.Context, d *schema.Resourc
 		d.Set("labels", labels)
 	}
 
-	// Convert sm.Settings...
+	d.Set("settings", flattenCheckSettings(chk.Settings))
 
+	return nil
+}
+
+// flattenCheckSettings converts the settings of a check into the schema's settings set.
+func flattenCheckSettings(cs smCheckSettings) *schema.Set {
 	settings := schema.NewSet(
 		schema.HashResource(syntheticMonitoringCheckSettings),
 		[]interface{}{},
@@ -602,7 +856,7 @@ func resourceSyntheticMonitoringCheckRead(ctx context.Context, d *schema.Resourc
 	}
 
 	switch {
-	case chk.Settings.Dns != nil:
+	case cs.Dns != nil:
 		dns := schema.NewSet(
 			schema.HashResource(syntheticMonitoringCheckSettingsDNS),
 			[]interface{}{},
@@ -622,32 +876,32 @@ func resourceSyntheticMonitoringCheckRead(ctx context.Context, d *schema.Resourc
 			)
 		}
 		dns.Add(map[string]interface{}{
-			"ip_version":              chk.Settings.Dns.IpVersion.String(),
-			"source_ip_address":       chk.Settings.Dns.SourceIpAddress,
-			"server":                  chk.Settings.Dns.Server,
-			"port":                    int(chk.Settings.Dns.Port),
-			"record_type":             chk.Settings.Dns.RecordType.String(),
-			"protocol":                chk.Settings.Dns.Protocol.String(),
-			"valid_r_codes":           stringSliceToSet(chk.Settings.Dns.ValidRCodes),
-			"validate_answer_rrs":     dnsValidator(chk.Settings.Dns.ValidateAnswer),
-			"validate_authority_rrs":  dnsValidator(chk.Settings.Dns.ValidateAuthority),
-			"validate_additional_rrs": dnsValidator(chk.Settings.Dns.ValidateAdditional),
+			"ip_version":              cs.Dns.IpVersion.String(),
+			"source_ip_address":       cs.Dns.SourceIpAddress,
+			"server":                  cs.Dns.Server,
+			"port":                    int(cs.Dns.Port),
+			"record_type":             cs.Dns.RecordType.String(),
+			"protocol":                cs.Dns.Protocol.String(),
+			"valid_r_codes":           stringSliceToSet(cs.Dns.ValidRCodes),
+			"validate_answer_rrs":     dnsValidator(cs.Dns.ValidateAnswer),
+			"validate_authority_rrs":  dnsValidator(cs.Dns.ValidateAuthority),
+			"validate_additional_rrs": dnsValidator(cs.Dns.ValidateAdditional),
 		})
 		settings.Add(map[string]interface{}{
 			"dns": dns,
 		})
-	case chk.Settings.Http != nil:
+	case cs.Http != nil:
 		http := schema.NewSet(
 			schema.HashResource(syntheticMonitoringCheckSettingsPing),
 			[]interface{}{},
 		)
 		basicAuth := schema.Set{}
-		if chk.Settings.Http.BasicAuth != nil {
+		if cs.Http.BasicAuth != nil {
 			basicAuth = *schema.NewSet(schema.HashResource(syntheticMonitoringCheckSettingsHTTPBasicAuth),
 				[]interface{}{
 					map[string]interface{}{
-						"username": chk.Settings.Http.BasicAuth.Username,
-						"password": chk.Settings.Http.BasicAuth.Password,
+						"username": cs.Http.BasicAuth.Username,
+						"password": cs.Http.BasicAuth.Password,
 					},
 				},
 			)
@@ -667,44 +921,44 @@ func resourceSyntheticMonitoringCheckRead(ctx context.Context, d *schema.Resourc
 			return hmSet
 		}
 		http.Add(map[string]interface{}{
-			"ip_version":                        chk.Settings.Http.IpVersion.String(),
-			"tls_config":                        tlsConfig(chk.Settings.Http.TlsConfig),
-			"method":                            chk.Settings.Http.Method.String(),
-			"headers":                           stringSliceToSet(chk.Settings.Http.Headers),
-			"body":                              chk.Settings.Http.Body,
-			"no_follow_redirects":               chk.Settings.Http.NoFollowRedirects,
+			"ip_version":                        cs.Http.IpVersion.String(),
+			"tls_config":                        tlsConfig(cs.Http.TlsConfig),
+			"method":                            cs.Http.Method.String(),
+			"headers":                           stringSliceToSet(cs.Http.Headers),
+			"body":                              cs.Http.Body,
+			"no_follow_redirects":               cs.Http.NoFollowRedirects,
 			"basic_auth":                        &basicAuth,
-			"bearer_token":                      chk.Settings.Http.BearerToken,
-			"proxy_url":                         chk.Settings.Http.ProxyURL,
-			"fail_if_ssl":                       chk.Settings.Http.FailIfSSL,
-			"fail_if_not_ssl":                   chk.Settings.Http.FailIfNotSSL,
-			"valid_status_codes":                int32SliceToSet(chk.Settings.Http.ValidStatusCodes),
-			"valid_http_versions":               stringSliceToSet(chk.Settings.Http.ValidHTTPVersions),
-			"fail_if_body_matches_regexp":       stringSliceToSet(chk.Settings.Http.FailIfBodyMatchesRegexp),
-			"fail_if_body_not_matches_regexp":   stringSliceToSet(chk.Settings.Http.FailIfBodyNotMatchesRegexp),
-			"fail_if_header_matches_regexp":     headerMatch(chk.Settings.Http.FailIfHeaderMatchesRegexp),
-			"fail_if_header_not_matches_regexp": headerMatch(chk.Settings.Http.FailIfHeaderNotMatchesRegexp),
-			"cache_busting_query_param_name":    chk.Settings.Http.CacheBustingQueryParamName,
+			"bearer_token":                      cs.Http.BearerToken,
+			"proxy_url":                         cs.Http.ProxyURL,
+			"fail_if_ssl":                       cs.Http.FailIfSSL,
+			"fail_if_not_ssl":                   cs.Http.FailIfNotSSL,
+			"valid_status_codes":                int32SliceToSet(cs.Http.ValidStatusCodes),
+			"valid_http_versions":               stringSliceToSet(cs.Http.ValidHTTPVersions),
+			"fail_if_body_matches_regexp":       stringSliceToSet(cs.Http.FailIfBodyMatchesRegexp),
+			"fail_if_body_not_matches_regexp":   stringSliceToSet(cs.Http.FailIfBodyNotMatchesRegexp),
+			"fail_if_header_matches_regexp":     headerMatch(cs.Http.FailIfHeaderMatchesRegexp),
+			"fail_if_header_not_matches_regexp": headerMatch(cs.Http.FailIfHeaderNotMatchesRegexp),
+			"cache_busting_query_param_name":    cs.Http.CacheBustingQueryParamName,
 		})
 
 		settings.Add(map[string]interface{}{
 			"http": http,
 		})
-	case chk.Settings.Ping != nil:
+	case cs.Ping != nil:
 		ping := schema.NewSet(
 			schema.HashResource(syntheticMonitoringCheckSettingsPing),
 			[]interface{}{},
 		)
 		ping.Add(map[string]interface{}{
-			"ip_version":        chk.Settings.Ping.IpVersion.String(),
-			"source_ip_address": chk.Settings.Ping.SourceIpAddress,
-			"payload_size":      int(chk.Settings.Ping.PayloadSize),
-			"dont_fragment":     chk.Settings.Ping.DontFragment,
+			"ip_version":        cs.Ping.IpVersion.String(),
+			"source_ip_address": cs.Ping.SourceIpAddress,
+			"payload_size":      int(cs.Ping.PayloadSize),
+			"dont_fragment":     cs.Ping.DontFragment,
 		})
 		settings.Add(map[string]interface{}{
 			"ping": ping,
 		})
-	case chk.Settings.Tcp != nil:
+	case cs.Tcp != nil:
 		tcp := schema.NewSet(
 			schema.HashResource(syntheticMonitoringCheckSettingsTCP),
 			[]interface{}{},
@@ -713,7 +967,7 @@ func resourceSyntheticMonitoringCheckRead(ctx context.Context, d *schema.Resourc
 			schema.HashResource(syntheticMonitoringCheckSettingsTCPQueryResponse),
 			[]interface{}{},
 		)
-		for _, qr := range chk.Settings.Tcp.QueryResponse {
+		for _, qr := range cs.Tcp.QueryResponse {
 			queryResponse.Add(map[string]interface{}{
 				"send":      string(qr.Send),
 				"expect":    string(qr.Expect),
@@ -721,40 +975,120 @@ func resourceSyntheticMonitoringCheckRead(ctx context.Context, d *schema.Resourc
 			})
 		}
 		tcp.Add(map[string]interface{}{
-			"ip_version":        chk.Settings.Tcp.IpVersion.String(),
-			"tls_config":        tlsConfig(chk.Settings.Tcp.TlsConfig),
-			"source_ip_address": chk.Settings.Tcp.SourceIpAddress,
-			"tls":               chk.Settings.Tcp.Tls,
+			"ip_version":        cs.Tcp.IpVersion.String(),
+			"tls_config":        tlsConfig(cs.Tcp.TlsConfig),
+			"source_ip_address": cs.Tcp.SourceIpAddress,
+			"tls":               cs.Tcp.Tls,
 			"query_response":    queryResponse,
 		})
 		settings.Add(map[string]interface{}{
 			"tcp": tcp,
 		})
-	case chk.Settings.Traceroute != nil:
+	case cs.Traceroute != nil:
 		traceroute := schema.NewSet(
 			schema.HashResource(syntheticMonitoringCheckSettingsTraceroute),
 			[]interface{}{},
 		)
 
 		traceroute.Add(map[string]interface{}{
-			"max_hops":         int(chk.Settings.Traceroute.MaxHops),
-			"max_unknown_hops": int(chk.Settings.Traceroute.MaxUnknownHops),
-			"ptr_lookup":       chk.Settings.Traceroute.PtrLookup,
+			"max_hops":         int(cs.Traceroute.MaxHops),
+			"max_unknown_hops": int(cs.Traceroute.MaxUnknownHops),
+			"ptr_lookup":       cs.Traceroute.PtrLookup,
 		})
 		settings.Add(map[string]interface{}{
 			"traceroute": traceroute,
 		})
+	case cs.Multihttp != nil:
+		multihttp := schema.NewSet(
+			schema.HashResource(syntheticMonitoringCheckSettingsMultiHTTP),
+			[]interface{}{},
+		)
+		nameValues := func(nvs []smNameValue) []interface{} {
+			list := make([]interface{}, 0, len(nvs))
+			for _, nv := range nvs {
+				list = append(list, map[string]interface{}{
+					"name":  nv.Name,
+					"value": nv.Value,
+				})
+			}
+			return list
+		}
+		entries := make([]interface{}, 0, len(cs.Multihttp.Entries))
+		for _, e := range cs.Multihttp.Entries {
+			body := schema.NewSet(
+				schema.HashResource(syntheticMonitoringCheckSettingsMultiHTTPRequest.Schema["body"].Elem.(*schema.Resource)),
+				[]interface{}{},
+			)
+			if e.Request.Body != nil {
+				body.Add(map[string]interface{}{
+					"content_type":     e.Request.Body.ContentType,
+					"content_encoding": e.Request.Body.ContentEncoding,
+					"payload":          string(e.Request.Body.Payload),
+				})
+			}
+			request := schema.NewSet(
+				schema.HashResource(syntheticMonitoringCheckSettingsMultiHTTPRequest),
+				[]interface{}{
+					map[string]interface{}{
+						"method":       e.Request.Method.String(),
+						"url":          e.Request.URL,
+						"headers":      nameValues(e.Request.Headers),
+						"query_fields": nameValues(e.Request.QueryFields),
+						"body":         body,
+					},
+				},
+			)
+			assertions := make([]interface{}, 0, len(e.Assertions))
+			for _, a := range e.Assertions {
+				assertions = append(assertions, map[string]interface{}{
+					"type":       smEnumName(smMultiHTTPAssertionTypes, a.Type),
+					"subject":    smEnumName(smMultiHTTPAssertionSubjects, a.Subject),
+					"condition":  smEnumName(smMultiHTTPAssertionConditions, a.Condition),
+					"value":      a.Value,
+					"expression": a.Expression,
+				})
+			}
+			variables := make([]interface{}, 0, len(e.Variables))
+			for _, v := range e.Variables {
+				variables = append(variables, map[string]interface{}{
+					"type":       smEnumName(smMultiHTTPVariableTypes, v.Type),
+					"name":       v.Name,
+					"expression": v.Expression,
+					"attribute":  v.Attribute,
+				})
+			}
+			entries = append(entries, map[string]interface{}{
+				"request":    request,
+				"assertions": assertions,
+				"variables":  variables,
+			})
+		}
+		multihttp.Add(map[string]interface{}{
+			"entries": entries,
+		})
+		settings.Add(map[string]interface{}{
+			"multihttp": multihttp,
+		})
+	case cs.Scripted != nil:
+		scripted := schema.NewSet(
+			schema.HashResource(syntheticMonitoringCheckSettingsScripted),
+			[]interface{}{},
+		)
+		scripted.Add(map[string]interface{}{
+			"script": string(cs.Scripted.Script),
+		})
+		settings.Add(map[string]interface{}{
+			"scripted": scripted,
+		})
 	}
 
-	d.Set("settings", settings)
-
-	return nil
+	return settings
 }
 
 func resourceSyntheticMonitoringCheckUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	c := meta.(*client).smapi
+	c := meta.(*client)
 	chk := makeCheck(d)
-	_, err := c.UpdateCheck(ctx, *chk)
+	_, err := c.smUpdateCheck(ctx, *chk)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -773,9 +1107,9 @@ func resourceSyntheticMonitoringCheckDelete(ctx context.Context, d *schema.Resou
 	return diags
 }
 
-// makeCheck populates an instance of sm.Check. We need this for create and
-// update calls with the SM API client.
-func makeCheck(d *schema.ResourceData) *sm.Check {
+// makeCheck populates an instance of smCheck. We need this for create and
+// update calls to the SM API.
+func makeCheck(d *schema.ResourceData) *smCheck {
 	var id int64
 	if d.Id() != "" {
 		id, _ = strconv.ParseInt(d.Id(), 10, 64)
@@ -794,27 +1128,29 @@ func makeCheck(d *schema.ResourceData) *sm.Check {
 		})
 	}
 
-	return &sm.Check{
-		Id:               id,
-		TenantId:         int64(d.Get("tenant_id").(int)),
-		Job:              d.Get("job").(string),
-		Target:           d.Get("target").(string),
-		Frequency:        int64(d.Get("frequency").(int)),
-		Timeout:          int64(d.Get("timeout").(int)),
-		Enabled:          d.Get("enabled").(bool),
-		AlertSensitivity: d.Get("alert_sensitivity").(string),
-		BasicMetricsOnly: d.Get("basic_metrics_only").(bool),
-		Probes:           probes,
-		Labels:           labels,
-		Settings:         makeCheckSettings(d.Get("settings").(*schema.Set).List()[0].(map[string]interface{})),
+	return &smCheck{
+		Check: sm.Check{
+			Id:               id,
+			TenantId:         int64(d.Get("tenant_id").(int)),
+			Job:              d.Get("job").(string),
+			Target:           d.Get("target").(string),
+			Frequency:        int64(d.Get("frequency").(int)),
+			Timeout:          int64(d.Get("timeout").(int)),
+			Enabled:          d.Get("enabled").(bool),
+			AlertSensitivity: d.Get("alert_sensitivity").(string),
+			BasicMetricsOnly: d.Get("basic_metrics_only").(bool),
+			Probes:           probes,
+			Labels:           labels,
+		},
+		Settings: makeCheckSettings(d.Get("settings").(*schema.Set).List()[0].(map[string]interface{})),
 	}
 }
 
-// makeCheckSettings populates an instance of sm.CheckSettings. This is called
+// makeCheckSettings populates an instance of smCheckSettings. This is called
 // by makeCheck. It's isolated from makeCheck to hopefully make it all more
 // human readable.
-func makeCheckSettings(settings map[string]interface{}) sm.CheckSettings {
-	cs := sm.CheckSettings{}
+func makeCheckSettings(settings map[string]interface{}) smCheckSettings {
+	cs := smCheckSettings{}
 
 	tlsConfig := func(t *schema.Set) *sm.TLSConfig {
 		tc := t.List()[0].(map[string]interface{})
@@ -953,6 +1289,69 @@ func makeCheckSettings(settings map[string]interface{}) sm.CheckSettings {
 		}
 	}
 
+	multihttp := settings["multihttp"].(*schema.Set).List()
+	if len(multihttp) > 0 {
+		m := multihttp[0].(map[string]interface{})
+		nameValues := func(list []interface{}) []smNameValue {
+			nvs := []smNameValue{}
+			for _, nv := range list {
+				nvs = append(nvs, smNameValue{
+					Name:  nv.(map[string]interface{})["name"].(string),
+					Value: nv.(map[string]interface{})["value"].(string),
+				})
+			}
+			return nvs
+		}
+		cs.Multihttp = &smMultiHTTPSettings{}
+		for _, e := range m["entries"].([]interface{}) {
+			entry := e.(map[string]interface{})
+			r := entry["request"].(*schema.Set).List()[0].(map[string]interface{})
+			request := smMultiHTTPEntryRequest{
+				Method:      sm.HttpMethod(sm.HttpMethod_value[r["method"].(string)]),
+				URL:         r["url"].(string),
+				Headers:     nameValues(r["headers"].([]interface{})),
+				QueryFields: nameValues(r["query_fields"].([]interface{})),
+			}
+			if r["body"].(*schema.Set).Len() > 0 {
+				b := r["body"].(*schema.Set).List()[0].(map[string]interface{})
+				request.Body = &smMultiHTTPEntryRequestBody{
+					ContentType:     b["content_type"].(string),
+					ContentEncoding: b["content_encoding"].(string),
+					Payload:         []byte(b["payload"].(string)),
+				}
+			}
+			smEntry := smMultiHTTPEntry{Request: request}
+			for _, a := range entry["assertions"].([]interface{}) {
+				a := a.(map[string]interface{})
+				smEntry.Assertions = append(smEntry.Assertions, smMultiHTTPEntryAssertion{
+					Type:       smEnumValue(smMultiHTTPAssertionTypes, a["type"].(string)),
+					Subject:    smEnumValue(smMultiHTTPAssertionSubjects, a["subject"].(string)),
+					Condition:  smEnumValue(smMultiHTTPAssertionConditions, a["condition"].(string)),
+					Value:      a["value"].(string),
+					Expression: a["expression"].(string),
+				})
+			}
+			for _, v := range entry["variables"].([]interface{}) {
+				v := v.(map[string]interface{})
+				smEntry.Variables = append(smEntry.Variables, smMultiHTTPEntryVariable{
+					Type:       smEnumValue(smMultiHTTPVariableTypes, v["type"].(string)),
+					Name:       v["name"].(string),
+					Expression: v["expression"].(string),
+					Attribute:  v["attribute"].(string),
+				})
+			}
+			cs.Multihttp.Entries = append(cs.Multihttp.Entries, smEntry)
+		}
+	}
+
+	scripted := settings["scripted"].(*schema.Set).List()
+	if len(scripted) > 0 {
+		s := scripted[0].(map[string]interface{})
+		cs.Scripted = &smScriptedSettings{
+			Script: []byte(s["script"].(string)),
+		}
+	}
+
 	return cs
 }
 
@@ -960,21 +1359,253 @@ func makeCheckSettings(settings map[string]interface{}) sm.CheckSettings {
 // Ideally, we'd use `ExactlyOneOf` here but it doesn't support TypeSet.
 // Also, TypeSet doesn't support ValidateFunc.
 // To maintain backwards compatibility, we do a custom validation in the CustomizeDiff function.
+// The target, timeout and probes are also validated here, as they depend on other attributes.
 func resourceSyntheticMonitoringCheckCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
 	settingsList := diff.Get("settings").(*schema.Set).List()
 	if len(settingsList) == 0 {
-		return fmt.Errorf("at least one check setting must be defined")
+		return checkDiffError(cty.GetAttrPath("settings"), fmt.Errorf("at least one check setting must be defined"))
 	}
 	settings := settingsList[0].(map[string]interface{})
 
 	count := 0
+	checkType := ""
 	for k := range syntheticMonitoringCheckSettings.Schema {
-		count += len(settings[k].(*schema.Set).List())
+		if n := len(settings[k].(*schema.Set).List()); n > 0 {
+			count += n
+			checkType = k
+		}
 	}
 
 	if count != 1 {
-		return fmt.Errorf("exactly one check setting must be defined, got %d", count)
+		return checkDiffError(cty.GetAttrPath("settings"), fmt.Errorf("exactly one check setting must be defined, got %d", count))
+	}
+
+	var errs []error
+
+	if diff.NewValueKnown("target") {
+		if err := validateCheckTarget(checkType, diff.Get("target").(string)); err != nil {
+			errs = append(errs, checkDiffError(cty.GetAttrPath("target"), err))
+		}
+	}
+
+	if diff.NewValueKnown("timeout") && diff.NewValueKnown("frequency") {
+		if timeout, frequency := diff.Get("timeout").(int), diff.Get("frequency").(int); timeout > frequency {
+			errs = append(errs, checkDiffError(cty.GetAttrPath("timeout"), fmt.Errorf("the timeout (%d ms) must not be greater than the frequency (%d ms)", timeout, frequency)))
+		}
+	}
+
+	c, hasSMClient := meta.(*client)
+	hasSMClient = hasSMClient && c.smapi != nil && c.smToken != ""
+
+	_, hasProbeNames := diff.GetOk("probe_names")
+	_, hasProbeSelector := diff.GetOk("probe_selector")
+	switch {
+	case !diff.NewValueKnown("probe_names") || !diff.NewValueKnown("probe_selector"):
+		if err := diff.SetNewComputed("probes"); err != nil {
+			return err
+		}
+	case hasProbeNames || hasProbeSelector:
+		if !hasSMClient {
+			return fmt.Errorf("sm_access_token must be set to resolve probe_names or probe_selector")
+		}
+		if err := resolveCheckProbes(ctx, diff, c); err != nil {
+			errs = append(errs, err)
+		}
+	case hasSMClient && diff.NewValueKnown("probes") && diff.HasChange("probes"):
+		if err := validateCheckProbes(ctx, c, diff.Get("probes").(*schema.Set)); err != nil {
+			errs = append(errs, checkDiffError(cty.GetAttrPath("probes"), err))
+		}
+	}
+
+	if hasSMClient && (diff.Id() == "" || diff.HasChange("labels")) {
+		errs = append(errs, validateCheckQuota(ctx, diff, c)...)
 	}
 
+	return checkDiffErrors(errs)
+}
+
+// validateCheckQuota returns an error if creating the check or its labels would exceed the limits of the tenant.
+func validateCheckQuota(ctx context.Context, diff *schema.ResourceDiff, c *client) []error {
+	limits, err := c.smGetTenantLimits(ctx)
+	if err != nil {
+		// The limits will be enforced by the SM API on apply
+		log.Printf("[WARN] could not get the tenant limits to validate the check: %v", err)
+		return nil
+	}
+
+	var errs []error
+	if diff.Id() == "" && limits.MaxChecks > 0 {
+		checks, err := c.smListChecks(ctx)
+		if err != nil {
+			log.Printf("[WARN] could not list checks to validate the check: %v", err)
+		} else if int64(len(checks)) >= limits.MaxChecks {
+			errs = append(errs, fmt.Errorf("the tenant has reached its limit of %d checks", limits.MaxChecks))
+		}
+	}
+	if diff.NewValueKnown("labels") && limits.MaxMetricLabels > 0 {
+		if n := len(diff.Get("labels").(map[string]interface{})); int64(n) > limits.MaxMetricLabels {
+			errs = append(errs, checkDiffError(cty.GetAttrPath("labels"), fmt.Errorf("%d labels are set but the tenant allows at most %d", n, limits.MaxMetricLabels)))
+		}
+	}
+	return errs
+}
+
+// checkDiffError attaches the path of the invalid attribute to err, so that
+// Terraform reports it against that attribute. The path is also kept in the
+// message as it is lost when several errors are combined.
+func checkDiffError(path cty.Path, err error) error {
+	var attr []string
+	for _, step := range path {
+		switch step := step.(type) {
+		case cty.GetAttrStep:
+			attr = append(attr, step.Name)
+		case cty.IndexStep:
+			if step.Key.Type() == cty.Number {
+				attr = append(attr, step.Key.AsBigFloat().String())
+			}
+		}
+	}
+	return path.NewErrorf("%s: %w", strings.Join(attr, "."), err)
+}
+
+// checkDiffErrors returns nil, the single error as-is (keeping its attribute
+// path) or all the errors combined.
+func checkDiffErrors(errs []error) error {
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	}
+	return multierror.Append(nil, errs...)
+}
+
+var checkHostnameRegexp = regexp.MustCompile(`^([a-zA-Z0-9_]([a-zA-Z0-9_-]{0,61}[a-zA-Z0-9_])?)(\.([a-zA-Z0-9_]([a-zA-Z0-9_-]{0,61}[a-zA-Z0-9_])?))*\.?$`)
+
+func isValidCheckHost(host string) bool {
+	return net.ParseIP(host) != nil || (len(host) <= 253 && checkHostnameRegexp.MatchString(host))
+}
+
+// validateCheckTarget validates the form of the target, which depends on the type of check.
+func validateCheckTarget(checkType, target string) error {
+	switch checkType {
+	case "http", "multihttp":
+		u, err := url.Parse(target)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("%q is not valid for a %s check, it must be a URL with the http or https scheme", target, checkType)
+		}
+	case "dns", "ping", "traceroute":
+		if !isValidCheckHost(target) {
+			return fmt.Errorf("%q is not valid for a %s check, it must be a hostname or an IP address", target, checkType)
+		}
+	case "tcp":
+		host, port, err := net.SplitHostPort(target)
+		if err != nil || !isValidCheckHost(host) {
+			return fmt.Errorf("%q is not valid for a tcp check, it must be of the form <host>:<port>", target)
+		}
+		if p, err := strconv.Atoi(port); err != nil || p < 1 || p > 65535 {
+			return fmt.Errorf("%q is not valid for a tcp check, the port must be between 1 and 65535", target)
+		}
+	}
+	return nil
+}
+
+// resolveCheckProbes sets `probes` to the IDs of the probes matching `probe_names` or `probe_selector`.
+func resolveCheckProbes(ctx context.Context, diff *schema.ResourceDiff, c *client) error {
+	probes, err := c.smapi.ListProbes(ctx)
+	if err != nil {
+		return fmt.Errorf("listing probes: %w", err)
+	}
+
+	var ids []interface{}
+	if names, ok := diff.GetOk("probe_names"); ok {
+		probeIDs := make(map[string]int64, len(probes))
+		for _, p := range probes {
+			probeIDs[p.Name] = p.Id
+		}
+		var unknown []string
+		for _, name := range names.(*schema.Set).List() {
+			id, ok := probeIDs[name.(string)]
+			if !ok {
+				unknown = append(unknown, fmt.Sprintf("%q", name))
+				continue
+			}
+			ids = append(ids, int(id))
+		}
+		if len(unknown) > 0 {
+			sort.Strings(unknown)
+			return checkDiffError(cty.GetAttrPath("probe_names"), fmt.Errorf("unknown probe %s", strings.Join(unknown, ", ")))
+		}
+	} else {
+		// An empty `probe_selector {}` block is read as nil and selects all the probes
+		selector, _ := diff.Get("probe_selector").([]interface{})[0].(map[string]interface{})
+		for _, p := range probes {
+			if probeMatchesSelector(p, selector) {
+				ids = append(ids, int(p.Id))
+			}
+		}
+		if len(ids) == 0 {
+			return checkDiffError(cty.GetAttrPath("probe_selector").IndexInt(0), fmt.Errorf("no probe matches the selector"))
+		}
+	}
+
+	return diff.SetNew("probes", ids)
+}
+
+// probeMatchesSelector returns whether the probe is selected. A nil selector selects all the non-deprecated probes.
+func probeMatchesSelector(p sm.Probe, selector map[string]interface{}) bool {
+	if p.Deprecated {
+		return false
+	}
+	if region, _ := selector["region"].(string); region != "" && p.Region != region {
+		return false
+	}
+	visibility, _ := selector["visibility"].(string)
+	switch visibility {
+	case "public":
+		if !p.Public {
+			return false
+		}
+	case "private":
+		if p.Public {
+			return false
+		}
+	}
+	labels := make(map[string]string, len(p.Labels))
+	for _, l := range p.Labels {
+		labels[l.Name] = l.Value
+	}
+	selectorLabels, _ := selector["labels"].(map[string]interface{})
+	for name, value := range selectorLabels {
+		if labels[name] != value.(string) {
+			return false
+		}
+	}
+	return true
+}
+
+// validateCheckProbes returns an error if any of the probes doesn't exist.
+func validateCheckProbes(ctx context.Context, c *client, probes *schema.Set) error {
+	existing, err := c.smapi.ListProbes(ctx)
+	if err != nil {
+		// The probes will be validated by the SM API on apply
+		log.Printf("[WARN] could not list probes to validate the check: %v", err)
+		return nil
+	}
+	ids := make(map[int64]bool, len(existing))
+	for _, p := range existing {
+		ids[p.Id] = true
+	}
+
+	var unknown []int
+	for _, p := range probes.List() {
+		if !ids[int64(p.(int))] {
+			unknown = append(unknown, p.(int))
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Ints(unknown)
+		return fmt.Errorf("unknown probe IDs: %v", unknown)
+	}
 	return nil
 }
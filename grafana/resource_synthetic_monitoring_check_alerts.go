@@ -0,0 +1,140 @@
+package grafana
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func ResourceSyntheticMonitoringCheckAlerts() *schema.Resource {
+	return &schema.Resource{
+
+		Description: `
+Manages the alerts of a Synthetic Monitoring check. Unlike the check's
+` + "`alert_sensitivity`" + `, which only selects one of the predefined
+sensitivity levels, these alerts each have their own threshold. Only one
+` + "`grafana_synthetic_monitoring_check_alerts`" + ` resource should be defined per check.
+
+* [Official documentation](https://grafana.com/docs/grafana-cloud/synthetic-monitoring/configure-alerts/)
+`,
+
+		CreateContext: resourceSyntheticMonitoringCheckAlertsUpdate,
+		ReadContext:   resourceSyntheticMonitoringCheckAlertsRead,
+		UpdateContext: resourceSyntheticMonitoringCheckAlertsUpdate,
+		DeleteContext: resourceSyntheticMonitoringCheckAlertsDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"check_id": {
+				Description: "The ID of the check to manage alerts for.",
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"alerts": {
+				Description: "Alerts of the check.",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Description: "Name of the alert. One of `" + strings.Join(smCheckAlertNames, "`, `") + "`. " +
+								"`ProbeFailedExecutionsTooHigh` fires when the number of failed executions over `period` is above the threshold. " +
+								"`TLSTargetCertificateCloseToExpiring` fires when the target's certificate expires in less than `threshold` days. " +
+								"The `*RequestDurationTooHighAvg` alerts fire when the average request duration over `period` is above `threshold` milliseconds.",
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice(smCheckAlertNames, false),
+						},
+						"threshold": {
+							Description: "Threshold of the alert. Its unit depends on the alert, see `name`.",
+							Type:        schema.TypeFloat,
+							Required:    true,
+						},
+						"period": {
+							Description:  "Period over which the alert is evaluated, such as `5m`. Not used by `TLSTargetCertificateCloseToExpiring`.",
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringInSlice([]string{"5m", "10m", "15m", "20m", "30m", "1h"}, false),
+						},
+						"runbook_url": {
+							Description: "URL of a runbook describing how to handle the alert.",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceSyntheticMonitoringCheckAlertsUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*client)
+	checkID := int64(d.Get("check_id").(int))
+
+	var alerts []smCheckAlert
+	for _, a := range d.Get("alerts").(*schema.Set).List() {
+		a := a.(map[string]interface{})
+		alerts = append(alerts, smCheckAlert{
+			Name:       a["name"].(string),
+			Threshold:  a["threshold"].(float64),
+			Period:     a["period"].(string),
+			RunbookURL: a["runbook_url"].(string),
+		})
+	}
+	if err := c.smUpdateCheckAlerts(ctx, checkID, alerts); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(strconv.FormatInt(checkID, 10))
+	return resourceSyntheticMonitoringCheckAlertsRead(ctx, d, meta)
+}
+
+func resourceSyntheticMonitoringCheckAlertsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*client)
+	checkID, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	alerts, err := c.smGetCheckAlerts(ctx, checkID)
+	if err != nil {
+		if strings.Contains(err.Error(), "status: 404") {
+			log.Printf("[WARN] removing alerts of check %d from state because the check no longer exists", checkID)
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	list := make([]interface{}, 0, len(alerts))
+	for _, a := range alerts {
+		list = append(list, map[string]interface{}{
+			"name":        a.Name,
+			"threshold":   a.Threshold,
+			"period":      a.Period,
+			"runbook_url": a.RunbookURL,
+		})
+	}
+	d.Set("check_id", checkID)
+	d.Set("alerts", list)
+
+	return nil
+}
+
+func resourceSyntheticMonitoringCheckAlertsDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*client)
+	checkID, _ := strconv.ParseInt(d.Id(), 10, 64)
+	if err := c.smUpdateCheckAlerts(ctx, checkID, nil); err != nil && !strings.Contains(err.Error(), "status: 404") {
+		return diag.FromErr(err)
+	}
+	d.SetId("")
+	return nil
+}
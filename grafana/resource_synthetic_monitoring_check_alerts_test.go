@@ -0,0 +1,53 @@
+package grafana
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceSyntheticMonitoringCheckAlerts(t *testing.T) {
+	CheckCloudInstanceTestsEnabled(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExample(t, "resources/grafana_synthetic_monitoring_check_alerts/resource.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("grafana_synthetic_monitoring_check_alerts.main", "check_id", "grafana_synthetic_monitoring_check.main", "id"),
+					resource.TestCheckResourceAttr("grafana_synthetic_monitoring_check_alerts.main", "alerts.#", "3"),
+					resource.TestCheckTypeSetElemNestedAttrs("grafana_synthetic_monitoring_check_alerts.main", "alerts.*", map[string]string{
+						"name":      "ProbeFailedExecutionsTooHigh",
+						"threshold": "3",
+						"period":    "10m",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("grafana_synthetic_monitoring_check_alerts.main", "alerts.*", map[string]string{
+						"name":      "TLSTargetCertificateCloseToExpiring",
+						"threshold": "14",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs("grafana_synthetic_monitoring_check_alerts.main", "alerts.*", map[string]string{
+						"name":        "HTTPRequestDurationTooHighAvg",
+						"threshold":   "500",
+						"runbook_url": "https://example.com/runbooks/slow-requests",
+					}),
+				),
+			},
+			{
+				ResourceName:      "grafana_synthetic_monitoring_check_alerts.main",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccExample(t, "resources/grafana_synthetic_monitoring_check_alerts/resource_update.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("grafana_synthetic_monitoring_check_alerts.main", "alerts.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs("grafana_synthetic_monitoring_check_alerts.main", "alerts.*", map[string]string{
+						"name":      "ProbeFailedExecutionsTooHigh",
+						"threshold": "5",
+					}),
+				),
+			},
+		},
+	})
+}
@@ -1,10 +1,23 @@
 package grafana
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
 	"regexp"
+	"sort"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+
+	sm "github.com/grafana/synthetic-monitoring-agent/pkg/pb/synthetic_monitoring"
+	smapi "github.com/grafana/synthetic-monitoring-api-go-client"
 )
 
 func TestAccResourceSyntheticMonitoringCheck_dns(t *testing.T) {
@@ -235,6 +248,179 @@ func TestAccResourceSyntheticMonitoringCheck_traceroute(t *testing.T) {
 	})
 }
 
+func TestAccResourceSyntheticMonitoringCheck_multihttp(t *testing.T) {
+	CheckCloudInstanceTestsEnabled(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExample(t, "resources/grafana_synthetic_monitoring_check/multihttp_basic.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("grafana_synthetic_monitoring_check.multihttp", "id"),
+					resource.TestCheckResourceAttrSet("grafana_synthetic_monitoring_check.multihttp", "tenant_id"),
+					resource.TestCheckResourceAttr("grafana_synthetic_monitoring_check.multihttp", "job", "MultiHTTP defaults"),
+					resource.TestCheckResourceAttr("grafana_synthetic_monitoring_check.multihttp", "target", "https://grafana.com"),
+					resource.TestCheckResourceAttr("grafana_synthetic_monitoring_check.multihttp", "probes.0", "1"),
+					resource.TestCheckResourceAttr("grafana_synthetic_monitoring_check.multihttp", "labels.foo", "bar"),
+					resource.TestCheckResourceAttr("grafana_synthetic_monitoring_check.multihttp", "settings.0.multihttp.0.entries.#", "1"),
+					resource.TestCheckResourceAttr("grafana_synthetic_monitoring_check.multihttp", "settings.0.multihttp.0.entries.0.request.0.method", "GET"),
+					resource.TestCheckResourceAttr("grafana_synthetic_monitoring_check.multihttp", "settings.0.multihttp.0.entries.0.request.0.url", "https://grafana.com"),
+				),
+			},
+			{
+				Config: testAccExample(t, "resources/grafana_synthetic_monitoring_check/multihttp_complex.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("grafana_synthetic_monitoring_check.multihttp", "id"),
+					resource.TestCheckResourceAttrSet("grafana_synthetic_monitoring_check.multihttp", "tenant_id"),
+					resource.TestCheckResourceAttr("grafana_synthetic_monitoring_check.multihttp", "job", "MultiHTTP complex"),
+					resource.TestCheckResourceAttr("grafana_synthetic_monitoring_check.multihttp", "target", "https://grafana.com/api/plugins"),
+					resource.TestCheckResourceAttr("grafana_synthetic_monitoring_check.multihttp", "labels.foo", "baz"),
+					resource.TestCheckResourceAttr("grafana_synthetic_monitoring_check.multihttp", "settings.0.multihttp.0.entries.#", "2"),
+					resource.TestCheckResourceAttr("grafana_synthetic_monitoring_check.multihttp", "settings.0.multihttp.0.entries.0.request.0.query_fields.0.name", "orderBy"),
+					resource.TestCheckResourceAttr("grafana_synthetic_monitoring_check.multihttp", "settings.0.multihttp.0.entries.0.request.0.headers.0.value", "application/json"),
+					resource.TestCheckResourceAttr("grafana_synthetic_monitoring_check.multihttp", "settings.0.multihttp.0.entries.0.assertions.0.type", "TEXT"),
+					resource.TestCheckResourceAttr("grafana_synthetic_monitoring_check.multihttp", "settings.0.multihttp.0.entries.0.assertions.0.subject", "HTTP_STATUS_CODE"),
+					resource.TestCheckResourceAttr("grafana_synthetic_monitoring_check.multihttp", "settings.0.multihttp.0.entries.0.assertions.0.condition", "EQUALS"),
+					resource.TestCheckResourceAttr("grafana_synthetic_monitoring_check.multihttp", "settings.0.multihttp.0.entries.0.variables.0.name", "pluginSlug"),
+					resource.TestCheckResourceAttr("grafana_synthetic_monitoring_check.multihttp", "settings.0.multihttp.0.entries.1.request.0.method", "POST"),
+					resource.TestCheckResourceAttr("grafana_synthetic_monitoring_check.multihttp", "settings.0.multihttp.0.entries.1.request.0.body.0.content_type", "application/json"),
+					resource.TestCheckResourceAttr("grafana_synthetic_monitoring_check.multihttp", "settings.0.multihttp.0.entries.1.assertions.0.type", "JSON_PATH"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceSyntheticMonitoringCheck_scripted(t *testing.T) {
+	CheckCloudInstanceTestsEnabled(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExample(t, "resources/grafana_synthetic_monitoring_check/scripted_basic.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("grafana_synthetic_monitoring_check.scripted", "id"),
+					resource.TestCheckResourceAttrSet("grafana_synthetic_monitoring_check.scripted", "tenant_id"),
+					resource.TestCheckResourceAttr("grafana_synthetic_monitoring_check.scripted", "job", "Scripted defaults"),
+					resource.TestCheckResourceAttr("grafana_synthetic_monitoring_check.scripted", "target", "scripted"),
+					resource.TestMatchResourceAttr("grafana_synthetic_monitoring_check.scripted", "settings.0.scripted.0.script", regexp.MustCompile(`http\.get\('https://grafana\.com'\)`)),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceSyntheticMonitoringCheck_probeSelection(t *testing.T) {
+	CheckCloudInstanceTestsEnabled(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExample(t, "resources/grafana_synthetic_monitoring_check/probe_names.tf") +
+					testAccExample(t, "resources/grafana_synthetic_monitoring_check/probe_selector.tf") +
+					testAccExample(t, "data-sources/grafana_synthetic_monitoring_probes/data-source.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("grafana_synthetic_monitoring_check.probe_names", "probes.#", "2"),
+					resource.TestCheckTypeSetElemAttrPair("grafana_synthetic_monitoring_check.probe_names", "probes.*", "data.grafana_synthetic_monitoring_probes.main", "probes.Atlanta"),
+					resource.TestCheckTypeSetElemAttrPair("grafana_synthetic_monitoring_check.probe_names", "probes.*", "data.grafana_synthetic_monitoring_probes.main", "probes.Frankfurt"),
+					resource.TestCheckTypeSetElemAttrPair("grafana_synthetic_monitoring_check.probe_selector", "probes.*", "data.grafana_synthetic_monitoring_probes.main", "probes.Frankfurt"),
+				),
+			},
+			{
+				Config: `
+				resource "grafana_synthetic_monitoring_check" "probe_names" {
+				  job         = "Probe names"
+				  target      = "https://grafana.com"
+				  enabled     = false
+				  probe_names = ["Not a probe"]
+				  settings {
+				    http {}
+				  }
+				}`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`probe_names: unknown probe "Not a probe"`),
+			},
+		},
+	})
+}
+
+func TestProbeMatchesSelector(t *testing.T) {
+	IsUnitTest(t)
+
+	probe := sm.Probe{
+		Region: "EMEA",
+		Public: false,
+		Labels: []sm.Label{{Name: "env", Value: "prod"}},
+	}
+	for name, tc := range map[string]struct {
+		selector map[string]interface{}
+		matches  bool
+	}{
+		"empty":          {map[string]interface{}{}, true},
+		"region":         {map[string]interface{}{"region": "EMEA"}, true},
+		"other region":   {map[string]interface{}{"region": "AMER"}, false},
+		"private":        {map[string]interface{}{"visibility": "private"}, true},
+		"public":         {map[string]interface{}{"visibility": "public"}, false},
+		"labels":         {map[string]interface{}{"labels": map[string]interface{}{"env": "prod"}}, true},
+		"other labels":   {map[string]interface{}{"labels": map[string]interface{}{"env": "dev"}}, false},
+		"missing labels": {map[string]interface{}{"labels": map[string]interface{}{"team": "sm"}}, false},
+	} {
+		selector := map[string]interface{}{"region": "", "visibility": "", "labels": map[string]interface{}{}}
+		for k, v := range tc.selector {
+			selector[k] = v
+		}
+		if matches := probeMatchesSelector(probe, selector); matches != tc.matches {
+			t.Errorf("%s: expected match to be %t, got %t", name, tc.matches, matches)
+		}
+	}
+
+	if !probeMatchesSelector(probe, nil) {
+		t.Errorf("expected a nil selector to match")
+	}
+
+	probe.Deprecated = true
+	if probeMatchesSelector(probe, map[string]interface{}{"region": "", "visibility": "", "labels": map[string]interface{}{}}) {
+		t.Errorf("expected deprecated probes to never match")
+	}
+}
+
+func TestResolveCheckProbesEmptySelectorUnit(t *testing.T) {
+	IsUnitTest(t)
+
+	meta := testSMClient(t, map[string]string{
+		"/api/v1/probe/list": `[
+			{"id": 1, "name": "Atlanta", "region": "AMER", "public": true},
+			{"id": 2, "name": "Paris", "region": "EMEA", "public": true},
+			{"id": 3, "name": "Old", "region": "EMEA", "public": true, "deprecated": true}
+		]`,
+		"/api/v1/tenant/limits": `{}`,
+	})
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"job":            "test",
+		"target":         "grafana.com",
+		"probe_selector": []interface{}{map[string]interface{}{}},
+		"settings":       []interface{}{map[string]interface{}{"ping": []interface{}{map[string]interface{}{}}}},
+	})
+	diff, err := ResourceSyntheticMonitoringCheck().Diff(context.Background(), nil, config, meta)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var probes []string
+	for k, attr := range diff.Attributes {
+		if strings.HasPrefix(k, "probes.") && k != "probes.#" {
+			probes = append(probes, attr.New)
+		}
+	}
+	sort.Strings(probes)
+	if !reflect.DeepEqual(probes, []string{"1", "2"}) {
+		t.Errorf("expected the empty selector to select probes [1 2], got %v", probes)
+	}
+}
+
 func TestAccResourceSyntheticMonitoringCheck_noSettings(t *testing.T) {
 	CheckCloudInstanceTestsEnabled(t)
 
@@ -265,6 +451,221 @@ func TestAccResourceSyntheticMonitoringCheck_multiple(t *testing.T) {
 	})
 }
 
+func TestAccResourceSyntheticMonitoringCheck_invalidSettings(t *testing.T) {
+	CheckCloudInstanceTestsEnabled(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccResourceSyntheticMonitoringCheckInvalid("grafana.com", 60000, 3000, `http { fail_if_body_matches_regexp = ["(unclosed"] }`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`fail_if_body_matches_regexp.*error parsing regexp`),
+			},
+			{
+				Config:      testAccResourceSyntheticMonitoringCheckInvalid("grafana.com", 60000, 3000, "http {}"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`target: "grafana.com" is not valid for a http check`),
+			},
+			{
+				Config:      testAccResourceSyntheticMonitoringCheckInvalid("grafana.com", 60000, 3000, "tcp {}"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`target: "grafana.com" is not valid for a tcp check`),
+			},
+			{
+				Config:      testAccResourceSyntheticMonitoringCheckInvalid("grafana.com", 2000, 3000, "ping {}"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`timeout: the timeout \(3000 ms\) must not be greater than the frequency \(2000 ms\)`),
+			},
+			{
+				Config: testAccResourceSyntheticMonitoringCheckInvalid("grafana.com", 60000, 3000, "ping {}") + `
+				resource "grafana_synthetic_monitoring_check" "unknown_probe" {
+				  job      = "Unknown probe"
+				  target   = "grafana.com"
+				  enabled  = false
+				  probes   = [999999]
+				  settings {
+				    ping {}
+				  }
+				}`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`probes: unknown probe IDs: \[999999\]`),
+			},
+		},
+	})
+}
+
+func TestValidateCheckTarget(t *testing.T) {
+	IsUnitTest(t)
+
+	for _, tc := range []struct {
+		checkType string
+		target    string
+		valid     bool
+	}{
+		{"http", "https://grafana.com/path", true},
+		{"http", "grafana.com", false},
+		{"http", "ftp://grafana.com", false},
+		{"multihttp", "http://grafana.com", true},
+		{"dns", "grafana.com", true},
+		{"dns", "1.1.1.1", true},
+		{"ping", "2001:db8::1", true},
+		{"ping", "https://grafana.com", false},
+		{"traceroute", "grafana.com:80", false},
+		{"tcp", "grafana.com:443", true},
+		{"tcp", "[2001:db8::1]:443", true},
+		{"tcp", "grafana.com", false},
+		{"tcp", "grafana.com:99999", false},
+		{"scripted", "anything goes", true},
+	} {
+		err := validateCheckTarget(tc.checkType, tc.target)
+		if tc.valid && err != nil {
+			t.Errorf("expected %s target %q to be valid, got %v", tc.checkType, tc.target, err)
+		}
+		if !tc.valid && err == nil {
+			t.Errorf("expected %s target %q to be invalid", tc.checkType, tc.target)
+		}
+	}
+}
+
+func TestSyntheticMonitoringCheckCustomizeDiffUnit(t *testing.T) {
+	IsUnitTest(t)
+
+	for name, tc := range map[string]struct {
+		target    string
+		timeout   int
+		settings  map[string]interface{}
+		path      cty.Path
+		errRegexp string
+	}{
+		"no settings": {
+			target:    "grafana.com",
+			timeout:   3000,
+			settings:  map[string]interface{}{},
+			path:      cty.GetAttrPath("settings"),
+			errRegexp: "^settings: at least one check setting must be defined$",
+		},
+		"target": {
+			target:    "grafana.com",
+			timeout:   3000,
+			settings:  map[string]interface{}{"http": []interface{}{map[string]interface{}{}}},
+			path:      cty.GetAttrPath("target"),
+			errRegexp: `^target: "grafana.com" is not valid for a http check`,
+		},
+		"timeout": {
+			target:    "grafana.com",
+			timeout:   90000,
+			settings:  map[string]interface{}{"ping": []interface{}{map[string]interface{}{}}},
+			path:      cty.GetAttrPath("timeout"),
+			errRegexp: `^timeout: the timeout \(90000 ms\) must not be greater than the frequency \(60000 ms\)$`,
+		},
+	} {
+		config := terraform.NewResourceConfigRaw(map[string]interface{}{
+			"job":       "test",
+			"target":    tc.target,
+			"frequency": 60000,
+			"timeout":   tc.timeout,
+			"probes":    []interface{}{1},
+			"settings":  []interface{}{tc.settings},
+		})
+		_, err := ResourceSyntheticMonitoringCheck().Diff(context.Background(), nil, config, nil)
+
+		var pathErr cty.PathError
+		if !errors.As(err, &pathErr) {
+			t.Errorf("%s: expected a path error, got %v", name, err)
+			continue
+		}
+		if !pathErr.Path.Equals(tc.path) {
+			t.Errorf("%s: expected the error to point at %#v, got %#v", name, tc.path, pathErr.Path)
+		}
+		if !regexp.MustCompile(tc.errRegexp).MatchString(err.Error()) {
+			t.Errorf("%s: expected the error to match %q, got %q", name, tc.errRegexp, err.Error())
+		}
+	}
+}
+
+func TestSyntheticMonitoringCheckQuotaUnit(t *testing.T) {
+	IsUnitTest(t)
+
+	meta := testSMClient(t, map[string]string{
+		"/api/v1/probe/list":    `[{"id": 1, "name": "Atlanta"}]`,
+		"/api/v1/tenant/limits": `{"maxChecks": 2, "maxMetricLabels": 1}`,
+		"/api/v1/check/list":    `[{"id": 1}, {"id": 2}]`,
+	})
+	for name, tc := range map[string]struct {
+		state     *terraform.InstanceState
+		labels    map[string]interface{}
+		errRegexp string
+	}{
+		"new check": {
+			errRegexp: `the tenant has reached its limit of 2 checks`,
+		},
+		"existing check": {
+			state: &terraform.InstanceState{ID: "1", Attributes: map[string]string{"job": "test", "target": "grafana.com"}},
+		},
+		"labels": {
+			state:     &terraform.InstanceState{ID: "1", Attributes: map[string]string{"job": "test", "target": "grafana.com"}},
+			labels:    map[string]interface{}{"a": "1", "b": "2"},
+			errRegexp: `^labels: 2 labels are set but the tenant allows at most 1$`,
+		},
+	} {
+		config := terraform.NewResourceConfigRaw(map[string]interface{}{
+			"job":      "test",
+			"target":   "grafana.com",
+			"probes":   []interface{}{1},
+			"labels":   tc.labels,
+			"settings": []interface{}{map[string]interface{}{"ping": []interface{}{map[string]interface{}{}}}},
+		})
+		_, err := ResourceSyntheticMonitoringCheck().Diff(context.Background(), tc.state, config, meta)
+		switch {
+		case tc.errRegexp == "" && err != nil:
+			t.Errorf("%s: unexpected error: %v", name, err)
+		case tc.errRegexp != "" && (err == nil || !regexp.MustCompile(tc.errRegexp).MatchString(err.Error())):
+			t.Errorf("%s: expected an error matching %q, got %v", name, tc.errRegexp, err)
+		}
+	}
+}
+
+// testSMClient returns a client whose SM API responds to the given paths with the given bodies.
+func testSMClient(t *testing.T, responses map[string]string) *client {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := responses[r.URL.Path]
+		if !ok {
+			t.Errorf("unexpected request to %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, body)
+	}))
+	t.Cleanup(server.Close)
+
+	return &client{
+		smToken:      "token",
+		smURL:        server.URL,
+		smHTTPClient: server.Client(),
+		smapi:        smapi.NewClient(server.URL, "token", server.Client()),
+	}
+}
+
+func testAccResourceSyntheticMonitoringCheckInvalid(target string, frequency, timeout int, settings string) string {
+	return fmt.Sprintf(`
+data "grafana_synthetic_monitoring_probes" "main" {}
+
+resource "grafana_synthetic_monitoring_check" "invalid" {
+  job       = "Invalid"
+  target    = "%s"
+  enabled   = false
+  frequency = %d
+  timeout   = %d
+  probes = [
+    data.grafana_synthetic_monitoring_probes.main.probes.Atlanta,
+  ]
+  settings {
+    %s
+  }
+}`, target, frequency, timeout, settings)
+}
+
 const testAccResourceSyntheticMonitoringCheck_noSettings = `
 data "grafana_synthetic_monitoring_probes" "main" {}
 
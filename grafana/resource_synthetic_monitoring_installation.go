@@ -2,8 +2,12 @@ package grafana
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"net/http"
+	"strconv"
+	"strings"
 
 	smapi "github.com/grafana/synthetic-monitoring-api-go-client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -16,15 +20,26 @@ func ResourceSyntheticMonitoringInstallation() *schema.Resource {
 		Description: `
 Sets up Synthetic Monitoring on a Grafana cloud stack and generates a token. 
 Once a Grafana Cloud stack is created, a user can either use this resource or go into the UI to install synthetic monitoring.
-This resource cannot be imported but it can be used on an existing Synthetic Monitoring installation without issues.
+This resource can be used on an existing Synthetic Monitoring installation without issues.
+It can also be imported, in which case the existing ` + "`sm_access_token`" + ` must be given in the import ID.
 
 * [Official documentation](https://grafana.com/docs/grafana-cloud/synthetic-monitoring/installation/)
 * [API documentation](https://github.com/grafana/synthetic-monitoring-api-go-client/blob/main/docs/API.md#apiv1registerinstall)
 `,
 		CreateContext: ResourceSyntheticMonitoringInstallationCreate,
+		ReadContext:   ResourceSyntheticMonitoringInstallationRead,
+		UpdateContext: ResourceSyntheticMonitoringInstallationUpdate,
 		DeleteContext: ResourceSyntheticMonitoringInstallationDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importSyntheticMonitoringInstallationState,
+		},
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+			if d.Id() != "" && d.HasChange("rotate_token") {
+				return d.SetNewComputed("sm_access_token")
+			}
+			return nil
+		},
 
-		ReadContext: func(ctx context.Context, rd *schema.ResourceData, i interface{}) diag.Diagnostics { return nil },
 		Schema: map[string]*schema.Schema{
 			"metrics_publisher_key": {
 				Type:        schema.TypeString,
@@ -32,6 +47,10 @@ This resource cannot be imported but it can be used on an existing Synthetic Mon
 				Required:    true,
 				ForceNew:    true,
 				Description: "The Cloud API Key with the `MetricsPublisher` role used to publish metrics to the SM API",
+				// The key isn't known after an import. It is only used to install SM, so don't reinstall because of that.
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return old == "" && d.Id() != ""
+				},
 			},
 			"stack_id": {
 				Type:        schema.TypeInt,
@@ -56,6 +75,12 @@ This resource cannot be imported but it can be used on an existing Synthetic Mon
 				Computed:    true,
 				Description: "Generated token to access the SM API.",
 			},
+			"rotate_token": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "Changing this value generates a new `sm_access_token` and revokes the previous one, without reinstalling SM. " +
+					"It can be set to any value, such as a date or a counter.",
+			},
 		},
 	}
 }
@@ -73,11 +98,15 @@ func ResourceSyntheticMonitoringInstallationCreate(ctx context.Context, d *schem
 }
 
 // Management of the installation is a one-off operation. The state cannot be updated through a read operation.
-// This read function will only invalidate the state (forcing recreation) if the installation has been deleted.
+// This read function will only invalidate the state (forcing recreation) if the installation has been deleted
+// or its token has been revoked.
 func ResourceSyntheticMonitoringInstallationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	provider := meta.(*client)
 	tempClient := smapi.NewClient(provider.smURL, d.Get("sm_access_token").(string), nil)
 	if err := tempClient.ValidateToken(ctx); err != nil {
+		if !isInvalidSMTokenError(err) {
+			return diag.FromErr(err)
+		}
 		log.Printf("[WARN] removing SM installation from state because it is no longer valid")
 		d.SetId("")
 	}
@@ -85,6 +114,35 @@ func ResourceSyntheticMonitoringInstallationRead(ctx context.Context, d *schema.
 	return nil
 }
 
+// isInvalidSMTokenError returns whether the token validation failed because the token is no longer valid,
+// rather than because the SM API couldn't be reached.
+func isInvalidSMTokenError(err error) bool {
+	var httpErr *smapi.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.Code == http.StatusUnauthorized || httpErr.Code == http.StatusForbidden || httpErr.Code == http.StatusNotFound
+	}
+	return errors.Is(err, smapi.ErrUnexpectedResponse) || errors.Is(err, smapi.ErrAuthorizationTokenRequired)
+}
+
+// ResourceSyntheticMonitoringInstallationUpdate rotates the access token. All other attributes force a new installation.
+func ResourceSyntheticMonitoringInstallationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if d.HasChange("rotate_token") {
+		provider := meta.(*client)
+		oldToken, _ := d.GetChange("sm_access_token")
+		tempClient := smapi.NewClient(provider.smURL, oldToken.(string), nil)
+		newToken, err := tempClient.CreateToken(ctx)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		d.Set("sm_access_token", newToken)
+		if err := tempClient.DeleteToken(ctx); err != nil {
+			return diag.Errorf("a new token was created but the previous one could not be revoked: %v", err)
+		}
+	}
+
+	return ResourceSyntheticMonitoringInstallationRead(ctx, d, meta)
+}
+
 func ResourceSyntheticMonitoringInstallationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	provider := meta.(*client)
 	tempClient := smapi.NewClient(provider.smURL, d.Get("sm_access_token").(string), nil)
@@ -94,3 +152,34 @@ func ResourceSyntheticMonitoringInstallationDelete(ctx context.Context, d *schem
 	d.SetId("")
 	return nil
 }
+
+// importSyntheticMonitoringInstallationState imports an existing installation from an ID of the form
+// `stack_id-metrics_instance_id-logs_instance_id:sm_access_token`.
+func importSyntheticMonitoringInstallationState(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	errInvalidID := fmt.Errorf("invalid id %q, expected format 'stack_id-metrics_instance_id-logs_instance_id:sm_access_token'", d.Id())
+
+	parts := strings.SplitN(d.Id(), ":", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return nil, errInvalidID
+	}
+	ids := strings.Split(parts[0], "-")
+	if len(ids) != 3 {
+		return nil, errInvalidID
+	}
+	var intIDs [3]int
+	for i, id := range ids {
+		intID, err := strconv.Atoi(id)
+		if err != nil {
+			return nil, errInvalidID
+		}
+		intIDs[i] = intID
+	}
+
+	d.SetId(parts[0])
+	d.Set("stack_id", intIDs[0])
+	d.Set("metrics_instance_id", intIDs[1])
+	d.Set("logs_instance_id", intIDs[2])
+	d.Set("sm_access_token", parts[1])
+
+	return []*schema.ResourceData{d}, nil
+}
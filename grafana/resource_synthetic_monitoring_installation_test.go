@@ -1,11 +1,15 @@
 package grafana
 
 import (
+	"context"
+	"fmt"
 	"testing"
 
 	gapi "github.com/grafana/grafana-api-golang-client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccSyntheticMonitoringInstallation(t *testing.T) {
@@ -16,6 +20,7 @@ func TestAccSyntheticMonitoringInstallation(t *testing.T) {
 	stackPrefix := "tfsminstalltest"
 	stackSlug := GetRandomStackName(stackPrefix)
 	apiKeyName := "zzztest-" + acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+	var firstToken string
 
 	resource.Test(t, resource.TestCase{
 		PreCheck: func() {
@@ -29,6 +34,34 @@ func TestAccSyntheticMonitoringInstallation(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					testAccStackCheckExists("grafana_cloud_stack.test", &stack),
 					resource.TestCheckResourceAttrSet("grafana_synthetic_monitoring_installation.test", "sm_access_token"),
+					func(s *terraform.State) error {
+						firstToken = s.RootModule().Resources["grafana_synthetic_monitoring_installation.test"].Primary.Attributes["sm_access_token"]
+						return nil
+					},
+				),
+			},
+			{
+				ResourceName:      "grafana_synthetic_monitoring_installation.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs := s.RootModule().Resources["grafana_synthetic_monitoring_installation.test"]
+					return rs.Primary.ID + ":" + rs.Primary.Attributes["sm_access_token"], nil
+				},
+				ImportStateVerifyIgnore: []string{"metrics_publisher_key"},
+			},
+			// Rotate the token
+			{
+				Config: testAccSyntheticMonitoringInstallationWithRotation(stackSlug, apiKeyName, "1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("grafana_synthetic_monitoring_installation.test", "rotate_token", "1"),
+					func(s *terraform.State) error {
+						newToken := s.RootModule().Resources["grafana_synthetic_monitoring_installation.test"].Primary.Attributes["sm_access_token"]
+						if newToken == "" || newToken == firstToken {
+							return fmt.Errorf("expected sm_access_token to be rotated")
+						}
+						return nil
+					},
 				),
 			},
 			// Test deletion
@@ -55,3 +88,58 @@ func testAccSyntheticMonitoringInstallation(stackSlug, apiKeyName string) string
 	}
 	`
 }
+
+func testAccSyntheticMonitoringInstallationWithRotation(stackSlug, apiKeyName, rotateToken string) string {
+	return testAccSyntheticMonitoringInstallation_Base(stackSlug, apiKeyName) +
+		fmt.Sprintf(`
+	resource "grafana_synthetic_monitoring_installation" "test" {
+		stack_id              = grafana_cloud_stack.test.id
+		metrics_instance_id   = grafana_cloud_stack.test.prometheus_user_id
+		logs_instance_id      = grafana_cloud_stack.test.logs_user_id
+		metrics_publisher_key = grafana_cloud_api_key.test.key
+		rotate_token          = "%s"
+	}
+	`, rotateToken)
+}
+
+func TestImportSyntheticMonitoringInstallationState(t *testing.T) {
+	IsUnitTest(t)
+
+	testcases := map[string]struct {
+		input       string
+		expectError bool
+	}{
+		"valid id and token":   {input: "1-2-3:token"},
+		"missing token":        {input: "1-2-3", expectError: true},
+		"empty token":          {input: "1-2-3:", expectError: true},
+		"missing instance ids": {input: "1-2:token", expectError: true},
+		"invalid instance id":  {input: "1-x-3:token", expectError: true},
+	}
+
+	for name, tc := range testcases {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, ResourceSyntheticMonitoringInstallation().Schema, nil)
+			d.SetId(tc.input)
+
+			res, err := importSyntheticMonitoringInstallationState(context.Background(), d, nil)
+			switch {
+			case tc.expectError && err == nil:
+				t.Fatalf("calling importSyntheticMonitoringInstallationState with id %q, expecting error, got nil", tc.input)
+			case !tc.expectError && err != nil:
+				t.Fatalf("calling importSyntheticMonitoringInstallationState with id %q, unexpected error: %v", tc.input, err)
+			case tc.expectError:
+				return
+			}
+
+			if res[0].Id() != "1-2-3" {
+				t.Errorf("expected id %q, got %q", "1-2-3", res[0].Id())
+			}
+			if res[0].Get("stack_id").(int) != 1 || res[0].Get("metrics_instance_id").(int) != 2 || res[0].Get("logs_instance_id").(int) != 3 {
+				t.Errorf("unexpected instance ids: %d, %d, %d", res[0].Get("stack_id"), res[0].Get("metrics_instance_id"), res[0].Get("logs_instance_id"))
+			}
+			if res[0].Get("sm_access_token").(string) != "token" {
+				t.Errorf("expected sm_access_token %q, got %q", "token", res[0].Get("sm_access_token"))
+			}
+		})
+	}
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -32,6 +34,12 @@ Grafana Synthetic Monitoring Agent.
 		Importer: &schema.ResourceImporter{
 			StateContext: importProbeStateWithToken,
 		},
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+			if d.Id() != "" && d.HasChange("reset_token") {
+				return d.SetNewComputed("auth_token")
+			}
+			return nil
+		},
 
 		Schema: map[string]*schema.Schema{
 			"id": {
@@ -86,6 +94,27 @@ Grafana Synthetic Monitoring Agent.
 				Optional:    true,
 				Default:     false,
 			},
+			"reset_token": {
+				Description: "Changing this value generates a new `auth_token` for the probe. The previous token stops working, so the probe must be restarted with the new one. " +
+					"It can be set to any value, such as a date or a counter.",
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"online": {
+				Description: "Whether the probe is currently connected to the Synthetic Monitoring API.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"last_seen": {
+				Description: "Time (RFC3339) at which the probe's `online` status last changed. For an offline probe, this is the last time it was seen.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"version": {
+				Description: "Version of the Synthetic Monitoring Agent run by the probe, as reported when it last connected.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
 		},
 	}
 }
@@ -120,6 +149,9 @@ func resourceSyntheticMonitoringProbeRead(ctx context.Context, d *schema.Resourc
 	d.Set("longitude", prb.Longitude)
 	d.Set("region", prb.Region)
 	d.Set("public", prb.Public)
+	d.Set("online", prb.Online)
+	d.Set("last_seen", probeLastSeen(prb))
+	d.Set("version", prb.Version)
 
 	if len(prb.Labels) > 0 {
 		// Convert []sm.Label into a map before set.
@@ -140,6 +172,13 @@ func resourceSyntheticMonitoringProbeUpdate(ctx context.Context, d *schema.Resou
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	if d.HasChange("reset_token") {
+		_, token, err := c.ResetProbeToken(ctx, *p)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		d.Set("auth_token", base64.StdEncoding.EncodeToString(token))
+	}
 	return resourceSyntheticMonitoringProbeRead(ctx, d, meta)
 }
 
@@ -155,6 +194,15 @@ func resourceSyntheticMonitoringProbeDelete(ctx context.Context, d *schema.Resou
 	return diags
 }
 
+// probeLastSeen returns the time at which the probe's online status last changed, formatted as RFC3339.
+func probeLastSeen(prb *sm.Probe) string {
+	if prb.OnlineChange == 0 {
+		return ""
+	}
+	sec, frac := math.Modf(prb.OnlineChange)
+	return time.Unix(int64(sec), int64(frac*1e9)).UTC().Format(time.RFC3339)
+}
+
 // makeProbe populates an instance of sm.Probe. We need this for create and
 // update calls with the SM API client.
 func makeProbe(d *schema.ResourceData) *sm.Probe {
@@ -1,14 +1,18 @@
 package grafana
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccResourceSyntheticMonitoringProbe(t *testing.T) {
 	CheckCloudInstanceTestsEnabled(t)
 
+	var authToken string
+
 	resource.Test(t, resource.TestCase{
 		ProviderFactories: testAccProviderFactories,
 		Steps: []resource.TestStep{
@@ -23,6 +27,11 @@ func TestAccResourceSyntheticMonitoringProbe(t *testing.T) {
 					resource.TestCheckResourceAttr("grafana_synthetic_monitoring_probe.main", "region", "APAC"),
 					resource.TestCheckResourceAttr("grafana_synthetic_monitoring_probe.main", "public", "false"),
 					resource.TestCheckResourceAttr("grafana_synthetic_monitoring_probe.main", "labels.type", "mountain"),
+					resource.TestCheckResourceAttr("grafana_synthetic_monitoring_probe.main", "online", "false"),
+					func(s *terraform.State) error {
+						authToken = s.RootModule().Resources["grafana_synthetic_monitoring_probe.main"].Primary.Attributes["auth_token"]
+						return nil
+					},
 				),
 			},
 			{
@@ -38,6 +47,20 @@ func TestAccResourceSyntheticMonitoringProbe(t *testing.T) {
 					resource.TestCheckResourceAttr("grafana_synthetic_monitoring_probe.main", "labels.type", "volcano"),
 				),
 			},
+			{
+				Config: testAccExample(t, "resources/grafana_synthetic_monitoring_probe/resource_reset_token.tf"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("grafana_synthetic_monitoring_probe.main", "name", "Mauna Loa"),
+					resource.TestCheckResourceAttr("grafana_synthetic_monitoring_probe.main", "reset_token", "2022-01-01"),
+					func(s *terraform.State) error {
+						newToken := s.RootModule().Resources["grafana_synthetic_monitoring_probe.main"].Primary.Attributes["auth_token"]
+						if newToken == "" || newToken == authToken {
+							return fmt.Errorf("expected auth_token to be reset")
+						}
+						return nil
+					},
+				),
+			},
 		},
 	})
 }
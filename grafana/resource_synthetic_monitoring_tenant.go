@@ -0,0 +1,178 @@
+package grafana
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	sm "github.com/grafana/synthetic-monitoring-agent/pkg/pb/synthetic_monitoring"
+)
+
+var syntheticMonitoringTenantRemote = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"name": {
+			Description: "Name of the remote.",
+			Type:        schema.TypeString,
+			Required:    true,
+		},
+		"url": {
+			Description: "URL of the remote.",
+			Type:        schema.TypeString,
+			Required:    true,
+		},
+		"username": {
+			Description: "Username used to authenticate with the remote. For Grafana Cloud, this is the ID of the metrics or logs instance.",
+			Type:        schema.TypeString,
+			Required:    true,
+		},
+		"password": {
+			Description: "Password used to authenticate with the remote. For Grafana Cloud, this is an API key with the `MetricsPublisher` role.",
+			Type:        schema.TypeString,
+			Required:    true,
+			Sensitive:   true,
+		},
+	},
+}
+
+func ResourceSyntheticMonitoringTenant() *schema.Resource {
+	return &schema.Resource{
+
+		Description: `
+Manages the settings of the Synthetic Monitoring tenant, which is the tenant the provider's ` + "`sm_access_token`" + ` belongs to.
+The tenant is created when Synthetic Monitoring is installed (see ` + "`grafana_synthetic_monitoring_installation`" + `),
+so creating this resource only updates the existing tenant and deleting it only removes it from the Terraform state.
+
+* [Official documentation](https://grafana.com/docs/grafana-cloud/synthetic-monitoring/)
+`,
+
+		CreateContext: resourceSyntheticMonitoringTenantUpdate,
+		ReadContext:   resourceSyntheticMonitoringTenantRead,
+		UpdateContext: resourceSyntheticMonitoringTenantUpdate,
+		DeleteContext: resourceSyntheticMonitoringTenantDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"stack_id": {
+				Description: "The ID of the Grafana Cloud stack the tenant is linked to.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+			},
+			"metrics_remote": {
+				Description: "Where the metrics produced by the checks are sent.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				MaxItems:    1,
+				Elem:        syntheticMonitoringTenantRemote,
+			},
+			"events_remote": {
+				Description: "Where the logs produced by the checks are sent.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				MaxItems:    1,
+				Elem:        syntheticMonitoringTenantRemote,
+			},
+			"org_id": {
+				Description: "The ID of the Grafana Cloud organization of the tenant.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"status": {
+				Description: "Status of the tenant. One of `ACTIVE`, `DISABLED`.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"reason": {
+				Description: "Reason for the tenant's status, if it isn't active.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceSyntheticMonitoringTenantUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*client).smapi
+	tenant, err := c.GetTenant(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if v, ok := d.GetOk("stack_id"); ok {
+		tenant.StackId = int64(v.(int))
+	}
+	if v, ok := d.GetOk("metrics_remote"); ok {
+		tenant.MetricsRemote = makeTenantRemote(v.([]interface{}))
+	}
+	if v, ok := d.GetOk("events_remote"); ok {
+		tenant.EventsRemote = makeTenantRemote(v.([]interface{}))
+	}
+
+	tenant, err = c.UpdateTenant(ctx, *tenant)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(strconv.FormatInt(tenant.Id, 10))
+	return resourceSyntheticMonitoringTenantRead(ctx, d, meta)
+}
+
+func resourceSyntheticMonitoringTenantRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(*client).smapi
+	tenant, err := c.GetTenant(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(strconv.FormatInt(tenant.Id, 10))
+	d.Set("org_id", tenant.OrgId)
+	d.Set("stack_id", tenant.StackId)
+	d.Set("status", tenant.Status.String())
+	d.Set("reason", tenant.Reason)
+	d.Set("metrics_remote", flattenTenantRemote(tenant.MetricsRemote, d.Get("metrics_remote").([]interface{})))
+	d.Set("events_remote", flattenTenantRemote(tenant.EventsRemote, d.Get("events_remote").([]interface{})))
+
+	return nil
+}
+
+// The tenant can't be deleted, it is only removed from the state.
+func resourceSyntheticMonitoringTenantDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	d.SetId("")
+	return nil
+}
+
+func makeTenantRemote(list []interface{}) *sm.RemoteInfo {
+	r := list[0].(map[string]interface{})
+	return &sm.RemoteInfo{
+		Name:     r["name"].(string),
+		Url:      r["url"].(string),
+		Username: r["username"].(string),
+		Password: r["password"].(string),
+	}
+}
+
+// flattenTenantRemote converts a remote into the schema's list. The API doesn't return passwords, so the one in the
+// current state is kept.
+func flattenTenantRemote(remote *sm.RemoteInfo, current []interface{}) []interface{} {
+	if remote == nil {
+		return []interface{}{}
+	}
+	password := remote.Password
+	if password == "" && len(current) > 0 && current[0] != nil {
+		password = current[0].(map[string]interface{})["password"].(string)
+	}
+	return []interface{}{
+		map[string]interface{}{
+			"name":     remote.Name,
+			"url":      remote.Url,
+			"username": remote.Username,
+			"password": password,
+		},
+	}
+}
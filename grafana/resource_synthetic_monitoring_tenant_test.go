@@ -0,0 +1,30 @@
+package grafana
+
+import (
+	"testing"
+
+	sm "github.com/grafana/synthetic-monitoring-agent/pkg/pb/synthetic_monitoring"
+)
+
+func TestFlattenTenantRemote(t *testing.T) {
+	IsUnitTest(t)
+
+	if remotes := flattenTenantRemote(nil, nil); len(remotes) != 0 {
+		t.Errorf("expected no remote, got %v", remotes)
+	}
+
+	current := []interface{}{map[string]interface{}{"name": "old", "url": "old", "username": "old", "password": "secret"}}
+	remote := &sm.RemoteInfo{Name: "metrics", Url: "https://prometheus.grafana.net", Username: "123"}
+	flattened := flattenTenantRemote(remote, current)[0].(map[string]interface{})
+	if flattened["name"] != "metrics" || flattened["url"] != "https://prometheus.grafana.net" || flattened["username"] != "123" {
+		t.Errorf("unexpected remote: %v", flattened)
+	}
+	if flattened["password"] != "secret" {
+		t.Errorf("expected the password in the state to be kept, got %q", flattened["password"])
+	}
+
+	remote.Password = "new-secret"
+	if password := flattenTenantRemote(remote, current)[0].(map[string]interface{})["password"]; password != "new-secret" {
+		t.Errorf("expected the password returned by the API to be used, got %q", password)
+	}
+}
@@ -2,14 +2,12 @@ package grafana
 
 import (
 	"context"
+	"fmt"
 	"strconv"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
-
-	gapi "github.com/grafana/grafana-api-golang-client"
 )
 
 func ResourceTeamPreferences() *schema.Resource {
@@ -25,49 +23,26 @@ func ResourceTeamPreferences() *schema.Resource {
 		UpdateContext: UpdateTeamPreferences,
 		DeleteContext: DeleteTeamPreferences,
 
-		Schema: map[string]*schema.Schema{
-			"team_id": {
-				Type:        schema.TypeInt,
-				Required:    true,
-				ForceNew:    true,
-				Description: "The numeric team ID.",
-			},
-			"theme": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				ValidateFunc: validation.StringInSlice([]string{"light", "dark", ""}, false),
-				Description:  "The theme for the specified team. Available themes are `light`, `dark`, or an empty string for the default theme.",
-			},
-			"home_dashboard_id": {
-				Type:        schema.TypeInt,
-				Optional:    true,
-				Description: "The numeric ID of the dashboard to display when a team member logs in.",
-			},
-			"timezone": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				ValidateFunc: validation.StringInSlice([]string{"utc", "browser", ""}, false),
-				Description:  "The timezone for the specified team. Available values are `utc`, `browser`, or an empty string for the default.",
-			},
-		},
+		Schema: teamPreferencesSchema(),
+	}
+}
+
+func teamPreferencesSchema() map[string]*schema.Schema {
+	s := preferencesSchema("team", "team member")
+	s["team_id"] = &schema.Schema{
+		Type:        schema.TypeInt,
+		Required:    true,
+		ForceNew:    true,
+		Description: "The numeric team ID.",
 	}
+	return s
 }
 
 func UpdateTeamPreferences(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*client).gapi
+	client := meta.(*client)
 
 	teamID := int64(d.Get("team_id").(int))
-	theme := d.Get("theme").(string)
-	homeDashboardID := int64(d.Get("home_dashboard_id").(int))
-	timezone := d.Get("timezone").(string)
-
-	preferences := gapi.Preferences{
-		Theme:           theme,
-		HomeDashboardID: homeDashboardID,
-		Timezone:        timezone,
-	}
-
-	err := client.UpdateTeamPreferences(teamID, preferences)
+	err := client.updatePreferences(ctx, fmt.Sprintf("/api/teams/%d/preferences", teamID), expandPreferences(d))
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -76,19 +51,17 @@ func UpdateTeamPreferences(ctx context.Context, d *schema.ResourceData, meta int
 }
 
 func ReadTeamPreferences(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*client).gapi
+	client := meta.(*client)
 
 	teamID := int64(d.Get("team_id").(int))
 
-	preferences, err := client.TeamPreferences(teamID)
+	preferences, err := client.getPreferences(ctx, fmt.Sprintf("/api/teams/%d/preferences", teamID))
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	d.SetId(strconv.FormatInt(teamID, 10))
-	d.Set("theme", preferences.Theme)
-	d.Set("home_dashboard_id", preferences.HomeDashboardID)
-	d.Set("timezone", preferences.Timezone)
+	flattenPreferences(d, preferences)
 
 	return nil
 }
@@ -98,12 +71,11 @@ func DeleteTeamPreferences(ctx context.Context, d *schema.ResourceData, meta int
 	// the specified preferences and go back to the default values. note: if the
 	// call fails because the team no longer exists - we'll just ignore the error
 
-	client := meta.(*client).gapi
+	client := meta.(*client)
 
 	teamID := int64(d.Get("team_id").(int))
-	defaultPreferences := gapi.Preferences{}
 
-	err := client.UpdateTeamPreferences(teamID, defaultPreferences)
+	err := client.updatePreferences(ctx, fmt.Sprintf("/api/teams/%d/preferences", teamID), preferences{})
 	if err != nil {
 		if strings.HasPrefix(err.Error(), "status: 404") {
 			d.SetId("")
@@ -28,6 +28,16 @@ func TestAccTeamPreferences_basic(t *testing.T) {
 					resource.TestCheckResourceAttr("grafana_team_preferences.testTeamPreferences", "timezone", "browser"),
 				),
 			},
+			{
+				Config: testAccTeamPreferencesConfig_Extended,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("grafana_team_preferences.testTeamPreferences", "theme", "system"),
+					resource.TestCheckResourceAttr("grafana_team_preferences.testTeamPreferences", "timezone", "Europe/Paris"),
+					resource.TestCheckResourceAttr("grafana_team_preferences.testTeamPreferences", "week_start", "monday"),
+					resource.TestCheckResourceAttr("grafana_team_preferences.testTeamPreferences", "home_dashboard_uid", "someuid"),
+					resource.TestCheckResourceAttrPair("grafana_team_preferences.testTeamPreferences", "home_dashboard_id", "grafana_dashboard.test", "dashboard_id"),
+				),
+			},
 		},
 	})
 }
@@ -84,3 +94,26 @@ resource "grafana_team_preferences" "testTeamPreferences" {
   timezone          = "browser"
 }
 `
+const testAccTeamPreferencesConfig_Extended = `
+resource "grafana_team" "testTeam" {
+  name = "terraform-test-team-preferences"
+}
+
+resource "grafana_dashboard" "test" {
+  config_json = <<EOT
+{
+  "title": "Terraform Team Preferences Acceptance Test",
+  "id": 13,
+  "version": "43",
+  "uid": "someuid"
+}
+EOT
+}
+resource "grafana_team_preferences" "testTeamPreferences" {
+  team_id            = grafana_team.testTeam.id
+  theme              = "system"
+  home_dashboard_uid = grafana_dashboard.test.uid
+  timezone           = "Europe/Paris"
+  week_start         = "monday"
+}
+`
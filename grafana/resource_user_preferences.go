@@ -0,0 +1,77 @@
+package grafana
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func ResourceUserPreferences() *schema.Resource {
+	return &schema.Resource{
+
+		Description: `
+Manages the preferences of the user the provider is authenticated as.
+Only one ` + "`grafana_user_preferences`" + ` resource should be defined per user.
+Destroying the resource resets the preferences to their defaults.
+
+**Note:** This resource can't be used with an API key, since API keys are not users.
+
+* [Official documentation](https://grafana.com/docs/grafana/latest/administration/preferences/)
+* [HTTP API](https://grafana.com/docs/grafana/latest/http_api/preferences/)
+`,
+
+		CreateContext: UpdateUserPreferences,
+		ReadContext:   ReadUserPreferences,
+		UpdateContext: UpdateUserPreferences,
+		DeleteContext: DeleteUserPreferences,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: preferencesSchema("user", "user"),
+	}
+}
+
+func UpdateUserPreferences(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*client)
+
+	if err := client.updatePreferences(ctx, "/api/user/preferences", expandPreferences(d)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return ReadUserPreferences(ctx, d, meta)
+}
+
+func ReadUserPreferences(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*client)
+
+	var user struct {
+		ID int64 `json:"id"`
+	}
+	if err := client.grafanaAPIRequest(ctx, http.MethodGet, "/api/user", nil, &user); err != nil {
+		return diag.FromErr(err)
+	}
+	preferences, err := client.getPreferences(ctx, "/api/user/preferences")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(strconv.FormatInt(user.ID, 10))
+	flattenPreferences(d, preferences)
+
+	return nil
+}
+
+func DeleteUserPreferences(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// There is no delete call for user preferences, they are reset to the defaults instead.
+	client := meta.(*client)
+
+	if err := client.updatePreferences(ctx, "/api/user/preferences", preferences{}); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
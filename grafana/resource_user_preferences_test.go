@@ -0,0 +1,32 @@
+package grafana
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccUserPreferences_basic(t *testing.T) {
+	CheckOSSTestsEnabled(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccExample(t, "resources/grafana_user_preferences/resource.tf"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("grafana_user_preferences.preferences", "id"),
+					resource.TestCheckResourceAttr("grafana_user_preferences.preferences", "theme", "dark"),
+					resource.TestCheckResourceAttr("grafana_user_preferences.preferences", "timezone", "Europe/Berlin"),
+					resource.TestCheckResourceAttr("grafana_user_preferences.preferences", "week_start", "monday"),
+					resource.TestCheckResourceAttr("grafana_user_preferences.preferences", "home_dashboard_uid", "my-home"),
+				),
+			},
+			{
+				ResourceName:      "grafana_user_preferences.preferences",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -1,8 +1,11 @@
 package grafana
 
 import (
+	"context"
+	"fmt"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -42,3 +45,31 @@ func cloneResourceSchemaForDatasource(r *schema.Resource, updates map[string]*sc
 	}
 	return clone
 }
+
+// deleteProtectionSchema returns the schema of the `delete_protection` attribute of resources that are
+// too critical to be deleted by mistake (as part of a destroy or a replacement).
+func deleteProtectionSchema(resourceDesc string) *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+		Description: fmt.Sprintf("Whether to prevent the deletion of the %[1]s. "+
+			"If true, any plan that deletes or replaces the %[1]s fails when applied. "+
+			"It must be set to false (and applied) before the %[1]s can be deleted.", resourceDesc),
+	}
+}
+
+// checkDeleteProtection returns an error if the resource had `delete_protection` enabled in its last applied state
+func checkDeleteProtection(d *schema.ResourceData, resourceDesc, name string) diag.Diagnostics {
+	if d.Get("delete_protection").(bool) {
+		return diag.Errorf("cannot delete %s %q: delete_protection is enabled. Set delete_protection to false and apply before deleting it", resourceDesc, name)
+	}
+	return nil
+}
+
+// importStateWithDeleteProtection is a passthrough importer that sets `delete_protection` to its default value,
+// as the setting isn't stored in the API and would otherwise be missing from the imported state.
+func importStateWithDeleteProtection(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	d.Set("delete_protection", false)
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,204 @@
+package grafana
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	sm "github.com/grafana/synthetic-monitoring-agent/pkg/pb/synthetic_monitoring"
+)
+
+// The synthetic-monitoring-agent models used by the SM API client don't support all check types.
+// The types below extend them with the missing settings, and the checks are sent to the SM API directly.
+
+// smCheck is a sm.Check whose settings may also contain MultiHTTP and scripted settings.
+type smCheck struct {
+	sm.Check
+	Settings smCheckSettings `json:"settings"`
+}
+
+type smCheckSettings struct {
+	sm.CheckSettings
+	Multihttp *smMultiHTTPSettings `json:"multihttp,omitempty"`
+	Scripted  *smScriptedSettings  `json:"scripted,omitempty"`
+}
+
+type smMultiHTTPSettings struct {
+	Entries []smMultiHTTPEntry `json:"entries"`
+}
+
+type smMultiHTTPEntry struct {
+	Request    smMultiHTTPEntryRequest     `json:"request"`
+	Assertions []smMultiHTTPEntryAssertion `json:"assertions,omitempty"`
+	Variables  []smMultiHTTPEntryVariable  `json:"variables,omitempty"`
+}
+
+type smMultiHTTPEntryRequest struct {
+	Method      sm.HttpMethod                `json:"method"`
+	URL         string                       `json:"url"`
+	Headers     []smNameValue                `json:"headers,omitempty"`
+	QueryFields []smNameValue                `json:"queryFields,omitempty"`
+	Body        *smMultiHTTPEntryRequestBody `json:"body,omitempty"`
+}
+
+type smMultiHTTPEntryRequestBody struct {
+	ContentType     string `json:"contentType,omitempty"`
+	ContentEncoding string `json:"contentEncoding,omitempty"`
+	Payload         []byte `json:"payload,omitempty"`
+}
+
+type smNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type smMultiHTTPEntryAssertion struct {
+	Type       int32  `json:"type"`
+	Subject    int32  `json:"subject,omitempty"`
+	Condition  int32  `json:"condition,omitempty"`
+	Value      string `json:"value,omitempty"`
+	Expression string `json:"expression,omitempty"`
+}
+
+type smMultiHTTPEntryVariable struct {
+	Type       int32  `json:"type"`
+	Name       string `json:"name"`
+	Expression string `json:"expression,omitempty"`
+	Attribute  string `json:"attribute,omitempty"`
+}
+
+type smScriptedSettings struct {
+	Script []byte `json:"script"`
+}
+
+// Enum values of the MultiHTTP settings. The index of each name is its value in the SM API.
+var (
+	smMultiHTTPAssertionTypes      = []string{"TEXT", "JSON_PATH_VALUE", "JSON_PATH", "REGEX"}
+	smMultiHTTPAssertionSubjects   = []string{"DEFAULT_SUBJECT", "RESPONSE_HEADERS", "HTTP_STATUS_CODE", "RESPONSE_BODY"}
+	smMultiHTTPAssertionConditions = []string{"DEFAULT_CONDITION", "NOT_CONTAINS", "EQUALS", "STARTS_WITH", "ENDS_WITH", "TYPE_OF", "CONTAINS"}
+	smMultiHTTPVariableTypes       = []string{"JSON_PATH", "REGEX", "CSS_SELECTOR"}
+)
+
+func smEnumValue(names []string, name string) int32 {
+	for i, n := range names {
+		if n == name {
+			return int32(i)
+		}
+	}
+	return 0
+}
+
+func smEnumName(names []string, value int32) string {
+	if value < 0 || int(value) >= len(names) {
+		return fmt.Sprintf("%d", value)
+	}
+	return names[value]
+}
+
+func (c *client) smAddCheck(ctx context.Context, check smCheck) (*smCheck, error) {
+	var result smCheck
+	if err := c.smAPIRequest(ctx, http.MethodPost, "/check/add", &check, &result); err != nil {
+		return nil, fmt.Errorf("check add request: %w", err)
+	}
+	return &result, nil
+}
+
+func (c *client) smGetCheck(ctx context.Context, id int64) (*smCheck, error) {
+	var result smCheck
+	if err := c.smAPIRequest(ctx, http.MethodGet, fmt.Sprintf("/check/%d", id), nil, &result); err != nil {
+		return nil, fmt.Errorf("check get request: %w", err)
+	}
+	return &result, nil
+}
+
+func (c *client) smUpdateCheck(ctx context.Context, check smCheck) (*smCheck, error) {
+	var result smCheck
+	if err := c.smAPIRequest(ctx, http.MethodPost, "/check/update", &check, &result); err != nil {
+		return nil, fmt.Errorf("check update request: %w", err)
+	}
+	return &result, nil
+}
+
+func (c *client) smListChecks(ctx context.Context) ([]smCheck, error) {
+	var result []smCheck
+	if err := c.smAPIRequest(ctx, http.MethodGet, "/check/list", nil, &result); err != nil {
+		return nil, fmt.Errorf("check list request: %w", err)
+	}
+	return result, nil
+}
+
+// smCheckType returns the type of the check, which is the name of its settings block.
+func smCheckType(cs smCheckSettings) string {
+	switch {
+	case cs.Dns != nil:
+		return "dns"
+	case cs.Http != nil:
+		return "http"
+	case cs.Ping != nil:
+		return "ping"
+	case cs.Tcp != nil:
+		return "tcp"
+	case cs.Traceroute != nil:
+		return "traceroute"
+	case cs.Multihttp != nil:
+		return "multihttp"
+	case cs.Scripted != nil:
+		return "scripted"
+	}
+	return ""
+}
+
+// smCheckAlert is an alert defined on a check. Alerts aren't supported by the SM API client yet.
+type smCheckAlert struct {
+	Name       string  `json:"name"`
+	Threshold  float64 `json:"threshold"`
+	Period     string  `json:"period,omitempty"`
+	RunbookURL string  `json:"runbookUrl,omitempty"`
+}
+
+type smCheckAlerts struct {
+	Alerts []smCheckAlert `json:"alerts"`
+}
+
+// Names of the alerts that can be defined on a check.
+var smCheckAlertNames = []string{
+	"ProbeFailedExecutionsTooHigh",
+	"TLSTargetCertificateCloseToExpiring",
+	"HTTPRequestDurationTooHighAvg",
+	"PingRequestDurationTooHighAvg",
+	"DNSRequestDurationTooHighAvg",
+}
+
+func (c *client) smGetCheckAlerts(ctx context.Context, checkID int64) ([]smCheckAlert, error) {
+	var result smCheckAlerts
+	if err := c.smAPIRequest(ctx, http.MethodGet, fmt.Sprintf("/check/%d/alerts", checkID), nil, &result); err != nil {
+		return nil, fmt.Errorf("check alerts get request: %w", err)
+	}
+	return result.Alerts, nil
+}
+
+func (c *client) smUpdateCheckAlerts(ctx context.Context, checkID int64, alerts []smCheckAlert) error {
+	if alerts == nil {
+		alerts = []smCheckAlert{}
+	}
+	if err := c.smAPIRequest(ctx, http.MethodPut, fmt.Sprintf("/check/%d/alerts", checkID), &smCheckAlerts{Alerts: alerts}, nil); err != nil {
+		return fmt.Errorf("check alerts update request: %w", err)
+	}
+	return nil
+}
+
+// smTenantLimits are the limits of the tenant. They aren't supported by the SM API client yet.
+type smTenantLimits struct {
+	MaxChecks       int64 `json:"maxChecks"`
+	MaxProbes       int64 `json:"maxProbes"`
+	MaxMetricLabels int64 `json:"maxMetricLabels"`
+	MaxLogLabels    int64 `json:"maxLogLabels"`
+}
+
+func (c *client) smGetTenantLimits(ctx context.Context) (*smTenantLimits, error) {
+	var result smTenantLimits
+	if err := c.smAPIRequest(ctx, http.MethodGet, "/tenant/limits", nil, &result); err != nil {
+		return nil, fmt.Errorf("tenant limits request: %w", err)
+	}
+	return &result, nil
+}